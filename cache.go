@@ -0,0 +1,218 @@
+package hfmodels
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// commitHashPattern matches full git commit hashes
+var commitHashPattern = regexp.MustCompile(`^[0-9a-f]{40}$`)
+
+// DefaultCacheDir returns the huggingface_hub cache directory shared with the
+// Python library: $HF_HUB_CACHE, else $HF_HOME/hub, else
+// $XDG_CACHE_HOME/huggingface/hub, else ~/.cache/huggingface/hub
+func DefaultCacheDir() string {
+	for _, env := range []string{"HF_HUB_CACHE", "HUGGINGFACE_HUB_CACHE"} {
+		if dir := os.Getenv(env); dir != "" {
+			return expandHome(dir)
+		}
+	}
+	if home := os.Getenv("HF_HOME"); home != "" {
+		return filepath.Join(expandHome(home), "hub")
+	}
+	if xdg := os.Getenv("XDG_CACHE_HOME"); xdg != "" {
+		return filepath.Join(expandHome(xdg), "huggingface", "hub")
+	}
+	return filepath.Join(expandHome("~"), ".cache", "huggingface", "hub")
+}
+
+// WithCacheDir stores downloads in dir instead of DefaultCacheDir
+func WithCacheDir(dir string) ClientOption {
+	return func(c *Client) {
+		if dir != "" {
+			c.cacheDir = expandHome(dir)
+		}
+	}
+}
+
+// CacheDir returns the cache directory the client downloads into
+func (c *Client) CacheDir() string {
+	if c.cacheDir != "" {
+		return c.cacheDir
+	}
+	return DefaultCacheDir()
+}
+
+// RepoCacheDir returns the cache folder of a model repository, e.g.
+// <cache>/models--google--gemma-2b
+func (c *Client) RepoCacheDir(repoID string) string {
+	return filepath.Join(c.CacheDir(), "models--"+strings.ReplaceAll(repoID, "/", "--"))
+}
+
+// cachedDownload downloads a file into the cache using the huggingface_hub
+// layout and returns the path of its snapshot entry:
+//
+//	models--org--name/
+//	├── blobs/<etag>                          file contents
+//	├── refs/<revision>                       commit hash the revision points to
+//	└── snapshots/<commit>/<filename>         symlink to ../../blobs/<etag>
+//
+// Files already in the cache, whether fetched by this library or by the
+// Python one, are not downloaded again.
+func (c *Client) cachedDownload(ctx context.Context, repoID, filename, revision string, cfg downloadConfig) (string, error) {
+	storage := c.RepoCacheDir(repoID)
+	relPath := filepath.FromSlash(filename)
+
+	// A commit hash can't move, so a cached snapshot file is always current
+	if commitHashPattern.MatchString(revision) {
+		pointer := filepath.Join(storage, "snapshots", revision, relPath)
+		if fileExists(pointer) {
+			return pointer, nil
+		}
+	}
+
+	meta, err := c.client.GetFileMetadata(ctx, c.client.ResolveURL(repoID, revision, filename))
+	if err != nil {
+		// Serve from the cache when the Hub can't be reached
+		var hubErr *HubError
+		if !errors.As(err, &hubErr) && ctx.Err() == nil {
+			if pointer, ok := cachedSnapshotFile(storage, revision, relPath); ok {
+				return pointer, nil
+			}
+		}
+		return "", err
+	}
+	if meta.CommitHash == "" || meta.ETag == "" {
+		return "", fmt.Errorf("the Hub did not report a commit hash and ETag for %s", filename)
+	}
+
+	if revision != meta.CommitHash {
+		if err := writeRef(storage, revision, meta.CommitHash); err != nil {
+			return "", err
+		}
+	}
+
+	pointer := filepath.Join(storage, "snapshots", meta.CommitHash, relPath)
+	if fileExists(pointer) {
+		return pointer, nil
+	}
+
+	blob := filepath.Join(storage, "blobs", meta.ETag)
+	if !fileExists(blob) {
+		unlock, err := lockBlob(c.CacheDir(), filepath.Base(storage), meta.ETag)
+		if err != nil {
+			return "", err
+		}
+		defer unlock()
+
+		// Another process may have finished the download while we waited for the lock
+		if !fileExists(blob) {
+			if err := c.fetchFile(ctx, meta, blob, cfg); err != nil {
+				return "", err
+			}
+		}
+	}
+
+	if err := linkSnapshotFile(blob, pointer); err != nil {
+		return "", err
+	}
+
+	return pointer, nil
+}
+
+// cachedSnapshotFile looks up a file in the snapshot a revision points to
+func cachedSnapshotFile(storage, revision, relPath string) (string, bool) {
+	commit := revision
+	if !commitHashPattern.MatchString(revision) {
+		ref, err := os.ReadFile(filepath.Join(storage, "refs", filepath.FromSlash(revision)))
+		if err != nil {
+			return "", false
+		}
+		commit = strings.TrimSpace(string(ref))
+	}
+
+	pointer := filepath.Join(storage, "snapshots", commit, relPath)
+	return pointer, fileExists(pointer)
+}
+
+// writeRef records the commit a revision points to
+func writeRef(storage, revision, commit string) error {
+	ref := filepath.Join(storage, "refs", filepath.FromSlash(revision))
+	if current, err := os.ReadFile(ref); err == nil && strings.TrimSpace(string(current)) == commit {
+		return nil
+	}
+
+	if err := os.MkdirAll(filepath.Dir(ref), 0o755); err != nil {
+		return fmt.Errorf("failed to create refs directory: %w", err)
+	}
+	if err := os.WriteFile(ref, []byte(commit), 0o644); err != nil {
+		return fmt.Errorf("failed to write ref: %w", err)
+	}
+	return nil
+}
+
+// linkSnapshotFile points a snapshot entry at its blob with a relative
+// symlink, copying the blob where symlinks aren't supported
+func linkSnapshotFile(blob, pointer string) error {
+	if err := os.MkdirAll(filepath.Dir(pointer), 0o755); err != nil {
+		return fmt.Errorf("failed to create snapshot directory: %w", err)
+	}
+
+	target, err := filepath.Rel(filepath.Dir(pointer), blob)
+	if err != nil {
+		return fmt.Errorf("failed to link snapshot file: %w", err)
+	}
+	if err := os.Symlink(target, pointer); err == nil || errors.Is(err, os.ErrExist) {
+		return nil
+	}
+
+	return copyFile(blob, pointer)
+}
+
+// copyFile copies src to dst through an incomplete file
+func copyFile(src, dst string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return fmt.Errorf("failed to open %s: %w", src, err)
+	}
+	defer in.Close()
+
+	tmp := dst + incompleteSuffix
+	out, err := os.Create(tmp)
+	if err != nil {
+		return fmt.Errorf("failed to create %s: %w", tmp, err)
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		return fmt.Errorf("failed to copy %s: %w", src, err)
+	}
+	if err := out.Close(); err != nil {
+		return fmt.Errorf("failed to copy %s: %w", src, err)
+	}
+
+	return os.Rename(tmp, dst)
+}
+
+// fileExists reports whether path exists, following symlinks
+func fileExists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
+
+// expandHome replaces a leading ~ with the user's home directory
+func expandHome(path string) string {
+	if path != "~" && !strings.HasPrefix(path, "~/") {
+		return path
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return path
+	}
+	return filepath.Join(home, strings.TrimPrefix(path, "~"))
+}
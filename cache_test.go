@@ -0,0 +1,215 @@
+package hfmodels
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// testCommit is the commit the fileServer reports
+var testCommit = strings.Repeat("c", 40)
+
+// noRetry fails requests at once, so that offline tests don't back off
+var noRetry = WithRetryPolicy(RetryPolicy{MaxAttempts: 1})
+
+func TestCachedDownload(t *testing.T) {
+	content := []byte("model weights")
+	etag := sha256Hex(content)
+	srv := newFileServer(t, content, etag, "honour")
+	cache := t.TempDir()
+	client := NewClient("", WithEndpoint(srv.URL), WithCacheDir(cache))
+
+	path, err := client.DownloadFile(context.Background(), "org/model", "model.bin", "", "")
+	if err != nil {
+		t.Fatalf("DownloadFile error: %v", err)
+	}
+
+	storage := filepath.Join(cache, "models--org--model")
+	if want := filepath.Join(storage, "snapshots", testCommit, "model.bin"); path != want {
+		t.Errorf("path = %s, want %s", path, want)
+	}
+	if target, err := os.Readlink(path); err != nil {
+		t.Errorf("the snapshot entry is not a symlink: %v", err)
+	} else if want := filepath.Join("..", "..", "blobs", etag); target != want {
+		t.Errorf("symlink target = %s, want %s", target, want)
+	}
+	if data, err := os.ReadFile(filepath.Join(storage, "blobs", etag)); err != nil || string(data) != string(content) {
+		t.Errorf("blob = %q, %v; want the file contents", data, err)
+	}
+	if ref, err := os.ReadFile(filepath.Join(storage, "refs", "main")); err != nil || string(ref) != testCommit {
+		t.Errorf("refs/main = %q, %v; want %s", ref, err, testCommit)
+	}
+	if data, err := os.ReadFile(path); err != nil || string(data) != string(content) {
+		t.Errorf("snapshot entry = %q, %v; want the file contents", data, err)
+	}
+
+	// A cached file is not downloaded again
+	if again, err := client.DownloadFile(context.Background(), "org/model", "model.bin", "", ""); err != nil || again != path {
+		t.Errorf("second DownloadFile = %s, %v; want %s", again, err, path)
+	}
+	if srv.gets != 1 {
+		t.Errorf("got %d GET requests, want 1", srv.gets)
+	}
+
+	// Nor is it looked up on the Hub at a commit hash, which can't move: the
+	// server only knows main and would answer 404
+	if got, err := client.DownloadFile(context.Background(), "org/model", "model.bin", testCommit, ""); err != nil || got != path {
+		t.Errorf("DownloadFile at the commit = %s, %v; want %s", got, err, path)
+	}
+}
+
+func TestCachedDownloadReusesBlob(t *testing.T) {
+	content := []byte("model weights")
+	etag := sha256Hex(content)
+	srv := newFileServer(t, content, etag, "honour")
+	cache := t.TempDir()
+
+	// A blob fetched for another revision, e.g. by huggingface_hub
+	blob := filepath.Join(cache, "models--org--model", "blobs", etag)
+	if err := os.MkdirAll(filepath.Dir(blob), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(blob, content, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	client := NewClient("", WithEndpoint(srv.URL), WithCacheDir(cache))
+	path, err := client.DownloadFile(context.Background(), "org/model", "model.bin", "", "")
+	if err != nil {
+		t.Fatalf("DownloadFile error: %v", err)
+	}
+	if srv.gets != 0 {
+		t.Errorf("got %d GET requests, want none", srv.gets)
+	}
+	if data, err := os.ReadFile(path); err != nil || string(data) != string(content) {
+		t.Errorf("snapshot entry = %q, %v; want the blob", data, err)
+	}
+}
+
+func TestCachedDownloadOffline(t *testing.T) {
+	content := []byte("model weights")
+	srv := newFileServer(t, content, sha256Hex(content), "honour")
+	cache := t.TempDir()
+
+	client := NewClient("", WithEndpoint(srv.URL), WithCacheDir(cache), noRetry)
+	path, err := client.DownloadFile(context.Background(), "org/model", "model.bin", "", "")
+	if err != nil {
+		t.Fatalf("DownloadFile error: %v", err)
+	}
+
+	// An error from the Hub is not papered over with the cache
+	if _, err := client.DownloadFile(context.Background(), "org/other", "model.bin", "", ""); !errors.Is(err, ErrRepoNotFound) {
+		t.Errorf("DownloadFile of a missing repository = %v, want %v", err, ErrRepoNotFound)
+	}
+	storage := filepath.Join(cache, "models--org--model")
+	stale := filepath.Join(storage, "snapshots", testCommit, "stale.bin")
+	if err := os.WriteFile(stale, content, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.DownloadFile(context.Background(), "org/model", "stale.bin", "", ""); err == nil {
+		t.Error("a file the Hub answers 404 for was served from the cache")
+	}
+
+	// Without the Hub, the revision is looked up in refs
+	srv.Close()
+	got, err := client.DownloadFile(context.Background(), "org/model", "model.bin", "", "")
+	if err != nil || got != path {
+		t.Errorf("offline DownloadFile = %s, %v; want the cached %s", got, err, path)
+	}
+	if _, err := client.DownloadFile(context.Background(), "org/model", "missing.bin", "", ""); err == nil {
+		t.Error("offline DownloadFile of an uncached file succeeded")
+	}
+	if _, err := client.DownloadFile(context.Background(), "org/model", "model.bin", "dev", ""); err == nil {
+		t.Error("offline DownloadFile at an unknown revision succeeded")
+	}
+}
+
+func TestDefaultCacheDir(t *testing.T) {
+	home := t.TempDir()
+	tests := []struct {
+		name string
+		env  map[string]string
+		want string
+	}{
+		{"HF_HUB_CACHE", map[string]string{"HF_HUB_CACHE": "/hub", "HUGGINGFACE_HUB_CACHE": "/legacy", "HF_HOME": "/hf", "XDG_CACHE_HOME": "/xdg"}, "/hub"},
+		{"HUGGINGFACE_HUB_CACHE", map[string]string{"HUGGINGFACE_HUB_CACHE": "/legacy", "HF_HOME": "/hf", "XDG_CACHE_HOME": "/xdg"}, "/legacy"},
+		{"HF_HOME", map[string]string{"HF_HOME": "/hf", "XDG_CACHE_HOME": "/xdg"}, filepath.Join("/hf", "hub")},
+		{"XDG_CACHE_HOME", map[string]string{"XDG_CACHE_HOME": "/xdg"}, filepath.Join("/xdg", "huggingface", "hub")},
+		{"home", nil, filepath.Join(home, ".cache", "huggingface", "hub")},
+		{"tilde", map[string]string{"HF_HOME": "~/hf"}, filepath.Join(home, "hf", "hub")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("HOME", home)
+			for _, env := range []string{"HF_HUB_CACHE", "HUGGINGFACE_HUB_CACHE", "HF_HOME", "XDG_CACHE_HOME"} {
+				t.Setenv(env, tt.env[env])
+			}
+			if got := DefaultCacheDir(); got != tt.want {
+				t.Errorf("DefaultCacheDir() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLocalDirDownload(t *testing.T) {
+	content := []byte("model weights")
+	etag := sha256Hex(content)
+	srv := newFileServer(t, content, etag, "honour")
+	dir := t.TempDir()
+	client := NewClient("", WithEndpoint(srv.URL))
+	ctx := context.Background()
+
+	path, err := client.localDirDownload(ctx, "org/model", "model.bin", "main", dir, downloadConfig{})
+	if err != nil {
+		t.Fatalf("localDirDownload error: %v", err)
+	}
+	if want := filepath.Join(dir, "model.bin"); path != want {
+		t.Errorf("path = %s, want %s", path, want)
+	}
+	if data, err := os.ReadFile(path); err != nil || string(data) != string(content) {
+		t.Errorf("file = %q, %v; want the file contents", data, err)
+	}
+
+	metaPath := filepath.Join(dir, ".cache", "huggingface", "download", "model.bin.metadata")
+	data, err := os.ReadFile(metaPath)
+	if err != nil {
+		t.Fatalf("metadata not written: %v", err)
+	}
+	lines := strings.Split(strings.TrimSuffix(string(data), "\n"), "\n")
+	if len(lines) != 3 || lines[0] != testCommit || lines[1] != etag {
+		t.Errorf("metadata = %q, want the commit, ETag and a timestamp", data)
+	}
+
+	// An unchanged file is kept, whether the revision is resolved again...
+	if _, err := client.localDirDownload(ctx, "org/model", "model.bin", "main", dir, downloadConfig{}); err != nil {
+		t.Fatalf("localDirDownload error: %v", err)
+	}
+	// ...or is the recorded commit, which needs no request: the server
+	// answers 404 for it
+	if _, err := client.localDirDownload(ctx, "org/model", "model.bin", testCommit, dir, downloadConfig{}); err != nil {
+		t.Fatalf("localDirDownload at the commit error: %v", err)
+	}
+	if srv.gets != 1 {
+		t.Errorf("got %d GET requests, want 1", srv.gets)
+	}
+
+	// A file recorded with another ETag changed on the Hub
+	if err := writeLocalDirMetadata(metaPath, testCommit, "old"); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte("old weights"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.localDirDownload(ctx, "org/model", "model.bin", "main", dir, downloadConfig{}); err != nil {
+		t.Fatalf("localDirDownload error: %v", err)
+	}
+	if data, _ := os.ReadFile(path); string(data) != string(content) {
+		t.Errorf("changed file not downloaded again: %q", data)
+	}
+	if commit, gotETag, ok := readLocalDirMetadata(metaPath); !ok || commit != testCommit || gotETag != etag {
+		t.Errorf("metadata = %s, %s, %v; want %s, %s", commit, gotETag, ok, testCommit, etag)
+	}
+}
@@ -0,0 +1,90 @@
+package hfmodels
+
+import (
+	"context"
+	"net/url"
+
+	"github.com/Megatherium/hf-go/internal/api"
+	"github.com/Megatherium/hf-go/internal/models"
+	"github.com/Megatherium/hf-go/internal/pkg/utils"
+)
+
+// Dataset represents a HuggingFace dataset in a listing
+type Dataset = models.Dataset
+
+// ListDatasetsOptions contains options for listing datasets
+type ListDatasetsOptions = models.ListDatasetsOptions
+
+// DatasetPager lazily iterates over the pages of a dataset listing
+type DatasetPager = api.DatasetPager
+
+// DatasetDetails contains detailed dataset information including files
+type DatasetDetails = models.DatasetDetails
+
+// DatasetCardData contains dataset card metadata
+type DatasetCardData = models.DatasetCardData
+
+// ListDatasets fetches datasets from HuggingFace Hub, following pagination up to opts.Limit
+func (c *Client) ListDatasets(opts ListDatasetsOptions) ([]Dataset, error) {
+	return c.ListDatasetsContext(context.Background(), opts)
+}
+
+// ListDatasetsContext is like ListDatasets but the requests are bound to ctx
+func (c *Client) ListDatasetsContext(ctx context.Context, opts ListDatasetsOptions) ([]Dataset, error) {
+	return c.client.ListDatasetsContext(ctx, opts)
+}
+
+// DatasetPages returns a lazy iterator over the pages of a dataset listing
+func (c *Client) DatasetPages(opts ListDatasetsOptions) *DatasetPager {
+	return c.DatasetPagesContext(context.Background(), opts)
+}
+
+// DatasetPagesContext is like DatasetPages but the page requests are bound to ctx
+func (c *Client) DatasetPagesContext(ctx context.Context, opts ListDatasetsOptions) *DatasetPager {
+	return c.client.DatasetPagesContext(ctx, opts)
+}
+
+// DatasetInfoOptions selects what DatasetInfoWithOptions fetches
+type DatasetInfoOptions struct {
+	// Revision is a branch, tag or commit hash; empty means the default branch
+	Revision string
+	// Blobs adds the size, blob ID and LFS information of every sibling
+	Blobs bool
+}
+
+// DatasetInfo fetches detailed information about a dataset
+func (c *Client) DatasetInfo(datasetID string) (*DatasetDetails, error) {
+	return c.DatasetInfoContext(context.Background(), datasetID)
+}
+
+// DatasetInfoContext is like DatasetInfo but the request is bound to ctx
+func (c *Client) DatasetInfoContext(ctx context.Context, datasetID string) (*DatasetDetails, error) {
+	return c.DatasetInfoWithOptions(ctx, datasetID, DatasetInfoOptions{})
+}
+
+// DatasetInfoWithOptions fetches dataset information at a revision,
+// optionally with the size of every file
+func (c *Client) DatasetInfoWithOptions(ctx context.Context, datasetID string, opts DatasetInfoOptions) (*DatasetDetails, error) {
+	path := "/api/datasets/" + datasetID
+	if opts.Revision != "" {
+		path += "/revision/" + url.PathEscape(opts.Revision)
+	}
+
+	params := url.Values{}
+	if opts.Blobs {
+		params.Set("blobs", "true")
+	}
+
+	var details DatasetDetails
+	if err := c.client.GetJSON(ctx, path, params, &details); err != nil {
+		return nil, err
+	}
+	details.Author = api.RepoAuthor(details.Author, details.ID)
+
+	return &details, nil
+}
+
+// FormatDatasets formats datasets in the named output format, like FormatModels
+func FormatDatasets(datasets []Dataset, format string) (string, error) {
+	return utils.FormatString(format, utils.DatasetTable(datasets))
+}
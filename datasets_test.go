@@ -0,0 +1,40 @@
+package hfmodels
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestDatasetInfoAuthor(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/datasets/org/data", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"id":"org/data"}`))
+	})
+	mux.HandleFunc("GET /api/datasets/org/authored", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"id":"org/authored","author":"someone"}`))
+	})
+	mux.HandleFunc("GET /api/datasets/squad", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"id":"squad"}`))
+	})
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	client := NewClient("", WithEndpoint(server.URL))
+	for id, want := range map[string]string{
+		"org/data":     "org",
+		"org/authored": "someone",
+		"squad":        "",
+	} {
+		t.Run(id, func(t *testing.T) {
+			details, err := client.DatasetInfoWithOptions(context.Background(), id, DatasetInfoOptions{})
+			if err != nil {
+				t.Fatalf("DatasetInfoWithOptions error: %v", err)
+			}
+			if details.Author != want {
+				t.Errorf("Author = %q, want %q", details.Author, want)
+			}
+		})
+	}
+}
@@ -0,0 +1,227 @@
+package hfmodels
+
+import (
+	"context"
+	"crypto/sha1"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+
+	"github.com/Megatherium/hf-go/internal/api"
+)
+
+// ErrChecksumMismatch is returned when a downloaded file doesn't match the
+// SHA256 (LFS files) or git blob ID (regular files) reported by the Hub
+var ErrChecksumMismatch = errors.New("checksum mismatch")
+
+// incompleteSuffix is appended to files while they are being downloaded
+const incompleteSuffix = ".incomplete"
+
+// ProgressFunc is called while a file downloads with the number of bytes
+// written so far (including resumed bytes) and the total size, or -1 if unknown
+type ProgressFunc func(downloaded, total int64)
+
+// DownloadOption configures a single download
+type DownloadOption func(*downloadConfig)
+
+type downloadConfig struct {
+	progress ProgressFunc
+}
+
+// WithProgress reports download progress to fn
+func WithProgress(fn ProgressFunc) DownloadOption {
+	return func(c *downloadConfig) {
+		c.progress = fn
+	}
+}
+
+// DownloadFile downloads filename at revision (default "main") from a model
+// repository and returns the path of the downloaded file.
+//
+// If dest is empty the file is stored in the huggingface_hub cache (see
+// CacheDir) and the returned path is its snapshot entry; files already in the
+// cache are not downloaded again. Otherwise the file is written to dest.
+//
+// The file is written to a ".incomplete" file and renamed once complete and
+// verified, so the destination never holds a partial file. An interrupted
+// download is resumed from the incomplete file with an HTTP Range request.
+func (c *Client) DownloadFile(ctx context.Context, repoID, filename, revision, dest string, opts ...DownloadOption) (string, error) {
+	var cfg downloadConfig
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	if revision == "" {
+		revision = DefaultRevision
+	}
+	if dest == "" {
+		return c.cachedDownload(ctx, repoID, filename, revision, cfg)
+	}
+
+	meta, err := c.client.GetFileMetadata(ctx, c.client.ResolveURL(repoID, revision, filename))
+	if err != nil {
+		return "", err
+	}
+
+	if err := c.fetchFile(ctx, meta, dest, cfg); err != nil {
+		return "", err
+	}
+
+	return dest, nil
+}
+
+// fetchFile downloads the file described by meta to dest through an
+// incomplete file, resuming and verifying it
+func (c *Client) fetchFile(ctx context.Context, meta *api.FileMetadata, dest string, cfg downloadConfig) error {
+	if err := os.MkdirAll(filepath.Dir(dest), 0o755); err != nil {
+		return fmt.Errorf("failed to create directory: %w", err)
+	}
+
+	tmpPath := dest + incompleteSuffix
+	tmp, err := os.OpenFile(tmpPath, os.O_RDWR|os.O_CREATE, 0o644)
+	if err != nil {
+		return fmt.Errorf("failed to create file: %w", err)
+	}
+	defer tmp.Close()
+
+	// Hash what is already on disk so that the checksum covers the whole file
+	hasher := newChecksum(meta.ETag, meta.Size)
+	var offset int64
+	if hasher != nil {
+		offset, err = io.Copy(hasher, tmp)
+	} else {
+		offset, err = tmp.Seek(0, io.SeekEnd)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read partial download: %w", err)
+	}
+
+	// A partial file as long as the whole file needs no request: keep it if
+	// it verifies, else start over rather than ask for an empty range
+	if meta.Size >= 0 && offset >= meta.Size {
+		if offset == meta.Size && (hasher == nil || hex.EncodeToString(hasher.Sum(nil)) == meta.ETag) {
+			if cfg.progress != nil {
+				cfg.progress(offset, meta.Size)
+			}
+			return commitDownload(tmp, tmpPath, dest)
+		}
+		offset = 0
+	}
+
+	header := http.Header{}
+	if offset > 0 {
+		header.Set("Range", fmt.Sprintf("bytes=%d-", offset))
+	}
+
+	resp, err := c.client.Fetch(ctx, meta.Location, header)
+	var hubErr *HubError
+	if errors.As(err, &hubErr) && hubErr.StatusCode == http.StatusRequestedRangeNotSatisfiable {
+		// The partial file is unusable, start over
+		offset = 0
+		resp, err = c.client.Fetch(ctx, meta.Location, nil)
+	}
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	// The server ignored the Range header and sent the whole file
+	if resp.StatusCode == http.StatusOK && offset > 0 {
+		offset = 0
+	}
+	if offset == 0 {
+		if err := tmp.Truncate(0); err != nil {
+			return fmt.Errorf("failed to truncate partial download: %w", err)
+		}
+		if _, err := tmp.Seek(0, io.SeekStart); err != nil {
+			return fmt.Errorf("failed to truncate partial download: %w", err)
+		}
+		hasher = newChecksum(meta.ETag, meta.Size)
+	}
+
+	total := meta.Size
+	if total < 0 && resp.ContentLength >= 0 {
+		total = offset + resp.ContentLength
+	}
+
+	var w io.Writer = tmp
+	if hasher != nil {
+		w = io.MultiWriter(tmp, hasher)
+	}
+	if cfg.progress != nil {
+		cfg.progress(offset, total)
+		w = io.MultiWriter(w, &progressWriter{written: offset, total: total, fn: cfg.progress})
+	}
+
+	written, err := io.Copy(w, resp.Body)
+	if err != nil {
+		return fmt.Errorf("failed to download %s: %w", meta.Location, err)
+	}
+	size := offset + written
+
+	if meta.Size >= 0 && size != meta.Size {
+		return fmt.Errorf("incomplete download: got %d of %d bytes", size, meta.Size)
+	}
+	if hasher != nil {
+		if sum := hex.EncodeToString(hasher.Sum(nil)); sum != meta.ETag {
+			tmp.Close()
+			os.Remove(tmpPath)
+			return fmt.Errorf("%w: expected %s, got %s", ErrChecksumMismatch, meta.ETag, sum)
+		}
+	}
+
+	return commitDownload(tmp, tmpPath, dest)
+}
+
+// commitDownload closes a complete incomplete file and moves it to dest
+func commitDownload(tmp *os.File, tmpPath, dest string) error {
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write file: %w", err)
+	}
+	if err := os.Rename(tmpPath, dest); err != nil {
+		return fmt.Errorf("failed to move download into place: %w", err)
+	}
+	return nil
+}
+
+// newChecksum returns the hash matching the ETag reported by the Hub: SHA256
+// for LFS files, or the git blob ID (SHA1 over a "blob <size>\0" header and the
+// contents) for regular files. It returns nil if the file can't be verified.
+func newChecksum(etag string, size int64) hash.Hash {
+	if _, err := hex.DecodeString(etag); err != nil {
+		return nil
+	}
+
+	switch len(etag) {
+	case sha256.Size * 2:
+		return sha256.New()
+	case sha1.Size * 2:
+		if size < 0 {
+			return nil
+		}
+		h := sha1.New()
+		fmt.Fprintf(h, "blob %d\x00", size)
+		return h
+	default:
+		return nil
+	}
+}
+
+// progressWriter reports the number of bytes written through it
+type progressWriter struct {
+	written int64
+	total   int64
+	fn      ProgressFunc
+}
+
+func (p *progressWriter) Write(b []byte) (int, error) {
+	p.written += int64(len(b))
+	p.fn(p.written, p.total)
+	return len(b), nil
+}
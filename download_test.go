@@ -0,0 +1,164 @@
+package hfmodels
+
+import (
+	"context"
+	"crypto/sha1"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// fileServer serves a single repository file through the resolve endpoint
+type fileServer struct {
+	*httptest.Server
+	content []byte
+	etag    string
+	// ranges is how GET requests with a Range header are answered: "honour",
+	// "ignore" (200 with the whole file) or "refuse" (416)
+	ranges string
+
+	mu     sync.Mutex
+	gets   int
+	ranged []string
+}
+
+func newFileServer(t *testing.T, content []byte, etag, ranges string) *fileServer {
+	t.Helper()
+	s := &fileServer{content: content, etag: etag, ranges: ranges}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/org/model/resolve/main/model.bin" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("X-Repo-Commit", strings.Repeat("c", 40))
+		w.Header().Set("ETag", `"`+s.etag+`"`)
+		if r.Method == http.MethodHead {
+			w.Header().Set("Content-Length", strconv.Itoa(len(s.content)))
+			return
+		}
+
+		s.mu.Lock()
+		s.gets++
+		rng := r.Header.Get("Range")
+		if rng != "" {
+			s.ranged = append(s.ranged, rng)
+		}
+		s.mu.Unlock()
+
+		var start int
+		if rng != "" && s.ranges != "ignore" {
+			fmt.Sscanf(rng, "bytes=%d-", &start)
+			if s.ranges == "refuse" || start >= len(s.content) {
+				w.WriteHeader(http.StatusRequestedRangeNotSatisfiable)
+				return
+			}
+			w.Header().Set("Content-Range", fmt.Sprintf("bytes %d-%d/%d", start, len(s.content)-1, len(s.content)))
+			w.Header().Set("Content-Length", strconv.Itoa(len(s.content)-start))
+			w.WriteHeader(http.StatusPartialContent)
+		}
+		w.Write(s.content[start:])
+	}))
+	t.Cleanup(s.Close)
+	return s
+}
+
+func sha256Hex(b []byte) string {
+	sum := sha256.Sum256(b)
+	return hex.EncodeToString(sum[:])
+}
+
+func gitBlobID(b []byte) string {
+	h := sha1.New()
+	fmt.Fprintf(h, "blob %d\x00", len(b))
+	h.Write(b)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+func TestDownloadFile(t *testing.T) {
+	content := []byte(strings.Repeat("0123456789", 100))
+	corrupt := append([]byte("XXXXXXXXXX"), content[10:]...)
+
+	tests := []struct {
+		name    string
+		etag    string
+		ranges  string
+		partial []byte
+		// wantRange is the Range header sent, "" if none
+		wantRange string
+		wantGets  int
+		wantErr   error
+	}{
+		{name: "fresh LFS file", etag: sha256Hex(content), ranges: "honour", wantGets: 1},
+		{name: "fresh regular file", etag: gitBlobID(content), ranges: "honour", wantGets: 1},
+		{name: "resume", etag: sha256Hex(content), ranges: "honour", partial: content[:10], wantRange: "bytes=10-", wantGets: 1},
+		{name: "resume regular file", etag: gitBlobID(content), ranges: "honour", partial: content[:500], wantRange: "bytes=500-", wantGets: 1},
+		{name: "restart on 416", etag: sha256Hex(content), ranges: "refuse", partial: content[:10], wantRange: "bytes=10-", wantGets: 2},
+		{name: "server ignores Range", etag: sha256Hex(content), ranges: "ignore", partial: content[:10], wantRange: "bytes=10-", wantGets: 1},
+		{name: "complete partial file", etag: sha256Hex(content), ranges: "honour", partial: content, wantGets: 0},
+		{name: "complete but corrupt partial file", etag: sha256Hex(content), ranges: "honour", partial: corrupt, wantGets: 1},
+		{name: "partial file longer than the file", etag: sha256Hex(content), ranges: "honour", partial: append(content, 'x'), wantGets: 1},
+		{name: "SHA256 mismatch", etag: sha256Hex(corrupt), ranges: "honour", wantGets: 1, wantErr: ErrChecksumMismatch},
+		{name: "git blob ID mismatch", etag: gitBlobID(corrupt), ranges: "honour", wantGets: 1, wantErr: ErrChecksumMismatch},
+		{name: "mismatch after resume", etag: sha256Hex(content), ranges: "honour", partial: corrupt[:10], wantRange: "bytes=10-", wantGets: 1, wantErr: ErrChecksumMismatch},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := newFileServer(t, content, tt.etag, tt.ranges)
+			dest := filepath.Join(t.TempDir(), "model.bin")
+			if tt.partial != nil {
+				if err := os.WriteFile(dest+incompleteSuffix, tt.partial, 0o644); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			var last [2]int64
+			client := NewClient("", WithEndpoint(srv.URL))
+			_, err := client.DownloadFile(context.Background(), "org/model", "model.bin", "", dest,
+				WithProgress(func(downloaded, total int64) { last = [2]int64{downloaded, total} }))
+
+			if srv.gets != tt.wantGets {
+				t.Errorf("got %d GET requests, want %d", srv.gets, tt.wantGets)
+			}
+			gotRange := strings.Join(srv.ranged, ",")
+			if gotRange != tt.wantRange {
+				t.Errorf("Range headers = %q, want %q", gotRange, tt.wantRange)
+			}
+
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("DownloadFile error = %v, want %v", err, tt.wantErr)
+				}
+				if _, err := os.Stat(dest); !os.IsNotExist(err) {
+					t.Error("the destination exists after a failed download")
+				}
+				if _, err := os.Stat(dest + incompleteSuffix); !os.IsNotExist(err) {
+					t.Error("the unverifiable partial file was kept")
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("DownloadFile error: %v", err)
+			}
+			if data, _ := os.ReadFile(dest); string(data) != string(content) {
+				t.Errorf("downloaded %d bytes that differ from the file", len(data))
+			}
+			if _, err := os.Stat(dest + incompleteSuffix); !os.IsNotExist(err) {
+				t.Error("the partial file was not moved into place")
+			}
+			if n := int64(len(content)); last != [2]int64{n, n} {
+				t.Errorf("last progress = %v, want %d of %d", last, n, n)
+			}
+		})
+	}
+}
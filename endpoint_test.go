@@ -0,0 +1,121 @@
+package hfmodels
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+)
+
+// hubServer is a fake Hub recording the requests it receives
+type hubServer struct {
+	*httptest.Server
+	mu    sync.Mutex
+	paths []string
+}
+
+func newHubServer(t *testing.T) *hubServer {
+	t.Helper()
+	s := &hubServer{}
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/models", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`[{"id":"org/model","downloads":1}]`))
+	})
+	mux.HandleFunc("GET /api/models/org/model", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"id":"org/model","siblings":[{"rfilename":"config.json"}]}`))
+	})
+	mux.HandleFunc("GET /api/models/org/model/tree/main", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`[{"type":"file","path":"config.json","size":2,"oid":"abc"}]`))
+	})
+	mux.HandleFunc("/org/model/resolve/main/config.json", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Repo-Commit", "0123456789abcdef0123456789abcdef01234567")
+		w.Header().Set("Content-Length", "2")
+		if r.Method == http.MethodGet {
+			w.Write([]byte("{}"))
+		}
+	})
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		s.paths = append(s.paths, r.Method+" "+r.URL.Path)
+		s.mu.Unlock()
+		mux.ServeHTTP(w, r)
+	}))
+	t.Cleanup(s.Close)
+	return s
+}
+
+// requests returns the method and path of every request received
+func (s *hubServer) requests() map[string]bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	seen := make(map[string]bool)
+	for _, p := range s.paths {
+		seen[p] = true
+	}
+	return seen
+}
+
+func TestWithEndpoint(t *testing.T) {
+	srv := newHubServer(t)
+	// WithEndpoint takes precedence over HF_ENDPOINT
+	t.Setenv("HF_ENDPOINT", "http://127.0.0.1:1")
+
+	client := NewClient("", WithEndpoint(srv.URL+"/"))
+	if client.Endpoint() != srv.URL {
+		t.Fatalf("Endpoint() = %q, want %q", client.Endpoint(), srv.URL)
+	}
+	ctx := context.Background()
+
+	if list, err := client.ListModels(ListModelsOptions{Limit: 1}); err != nil || len(list) != 1 {
+		t.Errorf("ListModels = %v, %v", list, err)
+	}
+	if details, err := client.GetModelDetails("org/model"); err != nil || details.ID != "org/model" {
+		t.Errorf("GetModelDetails = %v, %v", details, err)
+	}
+	if entries, err := client.ListRepoTree("org/model", "", "", false); err != nil || len(entries) != 1 {
+		t.Errorf("ListRepoTree = %v, %v", entries, err)
+	}
+	dest := filepath.Join(t.TempDir(), "config.json")
+	if _, err := client.DownloadFile(ctx, "org/model", "config.json", "", dest); err != nil {
+		t.Errorf("DownloadFile error: %v", err)
+	} else if data, _ := os.ReadFile(dest); string(data) != "{}" {
+		t.Errorf("downloaded %q, want {}", data)
+	}
+
+	seen := srv.requests()
+	for _, want := range []string{
+		"GET /api/models",
+		"GET /api/models/org/model",
+		"GET /api/models/org/model/tree/main",
+		"HEAD /org/model/resolve/main/config.json",
+		"GET /org/model/resolve/main/config.json",
+	} {
+		if !seen[want] {
+			t.Errorf("the server did not receive %s (got %v)", want, seen)
+		}
+	}
+}
+
+func TestEndpointFromEnv(t *testing.T) {
+	srv := newHubServer(t)
+	t.Setenv("HF_ENDPOINT", srv.URL+"/")
+
+	client := NewClient("")
+	if client.Endpoint() != srv.URL {
+		t.Fatalf("Endpoint() = %q, want %q", client.Endpoint(), srv.URL)
+	}
+	if _, err := client.GetModelDetails("org/model"); err != nil {
+		t.Fatalf("GetModelDetails error: %v", err)
+	}
+	if !srv.requests()["GET /api/models/org/model"] {
+		t.Error("the request did not go to HF_ENDPOINT")
+	}
+
+	t.Setenv("HF_ENDPOINT", "")
+	if got := NewClient("").Endpoint(); got != DefaultEndpoint {
+		t.Errorf("Endpoint() without HF_ENDPOINT = %q, want %q", got, DefaultEndpoint)
+	}
+}
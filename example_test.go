@@ -0,0 +1,173 @@
+package hfmodels_test
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	hfmodels "github.com/Megatherium/hf-go"
+)
+
+// Examples that talk to the Hub have no Output comment, so `go test` only
+// compiles them. Run them with `go test -run Example -v` and network access.
+
+func ExampleClient_ListModels() {
+	client := hfmodels.NewClient("") // Pass HF token if needed
+
+	modelsList, err := client.ListModels(hfmodels.ListModelsOptions{
+		Search: "bert",
+		Limit:  5,
+		Sort:   "downloads",
+	})
+	if err != nil {
+		log.Fatalf("Error listing models: %v", err)
+	}
+
+	fmt.Printf("Found %d models:\n", len(modelsList))
+	for _, model := range modelsList {
+		fmt.Printf("- %s (downloads: %d)\n", model.ID, model.Downloads)
+	}
+}
+
+func ExampleClient_ModelPages() {
+	client := hfmodels.NewClient("")
+
+	pager := client.ModelPages(hfmodels.ListModelsOptions{
+		Author: "google",
+	})
+	for pager.Next() {
+		for _, model := range pager.Page() {
+			fmt.Println(model.ID)
+		}
+	}
+	if err := pager.Err(); err != nil {
+		log.Fatalf("Error listing models: %v", err)
+	}
+}
+
+func ExampleClient_GetModelDetails() {
+	client := hfmodels.NewClient("")
+
+	details, err := client.GetModelDetails("microsoft/DialoGPT-medium")
+	if errors.Is(err, hfmodels.ErrRepoNotFound) {
+		log.Fatal("Model does not exist or is private")
+	}
+	if err != nil {
+		log.Fatalf("Error getting model details: %v", err)
+	}
+
+	fmt.Printf("Model: %s\n", details.ID)
+	fmt.Printf("Downloads: %d\n", details.Downloads)
+	fmt.Printf("License: %s\n", details.CardData.GetLicense())
+	fmt.Printf("Base Model: %s\n", details.CardData.GetBaseModel())
+}
+
+func ExampleClient_GetAvailableQuants() {
+	client := hfmodels.NewClient("")
+
+	quants, err := client.GetAvailableQuants("TheBloke/Llama-2-7B-GGUF")
+	if err != nil {
+		log.Fatalf("Error getting quants (might not be a GGUF model): %v", err)
+	}
+
+	fmt.Printf("Available quantizations: %v\n", quants)
+}
+
+func ExampleFormatModels() {
+	client := hfmodels.NewClient("")
+
+	modelsList, err := client.ListModels(hfmodels.ListModelsOptions{
+		PipelineTag: "text-generation",
+		Limit:       3,
+	})
+	if err != nil {
+		log.Fatalf("Error listing models: %v", err)
+	}
+
+	output, err := hfmodels.FormatModels(modelsList, "json")
+	if err != nil {
+		log.Fatalf("Error formatting models: %v", err)
+	}
+	fmt.Println(output)
+}
+
+func ExampleFormatTable() {
+	modelsList := []hfmodels.Model{
+		{
+			ID:           "google-bert/bert-base-uncased",
+			Author:       "google-bert",
+			Downloads:    51234567,
+			Likes:        2345,
+			LastModified: time.Date(2024, 2, 19, 0, 0, 0, 0, time.UTC),
+			LibraryName:  "transformers",
+			PipelineTag:  "fill-mask",
+		},
+	}
+
+	fmt.Println(hfmodels.FormatTable(modelsList))
+	// Output:
+	// ├───────────────────────────────┼─────────────┼────────────┼───────┼───────────────┼──────────────┼───────────┤
+	// │ Model ID                      │ Author      │ Downloads  │ Likes │ Last Modified │ Library      │ Task      │
+	// ├───────────────────────────────┼─────────────┼────────────┼───────┼───────────────┼──────────────┼───────────┤
+	// │ google-bert/bert-base-uncased │ google-bert │ 51,234,567 │ 2,345 │ 2024-02-19    │ transformers │ fill-mask │
+	// ├───────────────────────────────┼─────────────┼────────────┼───────┼───────────────┼──────────────┼───────────┤
+}
+
+func ExampleExtractQuantsFromSiblings() {
+	siblings := []hfmodels.Sibling{
+		{RFilename: "README.md"},
+		{RFilename: "llama-2-7b.Q4_K_M.gguf"},
+		{RFilename: "llama-2-7b.Q8_0.gguf"},
+		{RFilename: "BF16/llama-2-7b-BF16-00001-of-00002.gguf"},
+		{RFilename: "BF16/llama-2-7b-BF16-00002-of-00002.gguf"},
+	}
+
+	fmt.Println(hfmodels.ExtractQuantsFromSiblings(siblings))
+	// Output:
+	// [Q4_K_M Q8_0 BF16]
+}
+
+func ExampleGroupQuantVariants() {
+	siblings := []hfmodels.Sibling{
+		{RFilename: "Qwen3-235B-A22B-UD-Q2_K_XL-00002-of-00002.gguf"},
+		{RFilename: "Qwen3-235B-A22B-UD-Q2_K_XL-00001-of-00002.gguf"},
+		{RFilename: "Qwen3-235B-A22B-IQ4_XS.gguf"},
+		{RFilename: "BF16/Qwen3-235B-A22B-BF16-00001-of-00010.gguf"},
+	}
+
+	for _, v := range hfmodels.GroupQuantVariants(siblings) {
+		fmt.Printf("%s (%s, %.2f bpw, split: %t): %v\n", v.Name, v.Family, v.BitsPerWeight, v.Split(), v.Filenames())
+	}
+	// Output:
+	// UD-Q2_K_XL (k-quant, 2.96 bpw, split: true): [Qwen3-235B-A22B-UD-Q2_K_XL-00001-of-00002.gguf Qwen3-235B-A22B-UD-Q2_K_XL-00002-of-00002.gguf]
+	// IQ4_XS (i-quant, 4.25 bpw, split: false): [Qwen3-235B-A22B-IQ4_XS.gguf]
+	// BF16 (float, 16.00 bpw, split: true): [BF16/Qwen3-235B-A22B-BF16-00001-of-00010.gguf]
+}
+
+func ExampleQuantFiles() {
+	siblings := []hfmodels.Sibling{
+		{RFilename: "gemma-3-12b-it-Q4_K_M.gguf"},
+		{RFilename: "gemma-3-12b-it-Q8_0.gguf"},
+		{RFilename: "mmproj-model-f16.gguf"},
+		{RFilename: "mmproj-model-f32.gguf"},
+		{RFilename: "imatrix.dat"},
+	}
+
+	for _, v := range hfmodels.GroupQuantVariants(siblings) {
+		fmt.Printf("%s: %v\n", v.Role, v.Filenames())
+	}
+
+	files, err := hfmodels.QuantFiles(siblings, "q4_k_m")
+	if err != nil {
+		log.Fatal(err)
+	}
+	fmt.Println(files)
+	// Output:
+	// model: [gemma-3-12b-it-Q4_K_M.gguf]
+	// model: [gemma-3-12b-it-Q8_0.gguf]
+	// mmproj: [mmproj-model-f16.gguf]
+	// mmproj: [mmproj-model-f32.gguf]
+	// imatrix: [imatrix.dat]
+	// [gemma-3-12b-it-Q4_K_M.gguf mmproj-model-f16.gguf]
+}
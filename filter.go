@@ -0,0 +1,108 @@
+package hfmodels
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/Megatherium/hf-go/internal/filter"
+	"github.com/Megatherium/hf-go/internal/models"
+)
+
+// FilterExpr is a client-side filter expression over model fields, parsed by
+// ParseFilter or built with Where, HasTag, And, Or and Not
+type FilterExpr = filter.Expr
+
+// FilterField builds comparisons on a model field, see Where
+type FilterField = filter.FieldRef
+
+// FilterFields are the model fields filter expressions can test
+var FilterFields = models.FieldNames
+
+// ErrUnknownField is returned by ParseFilter for fields models don't have
+var ErrUnknownField = errors.New("unknown field")
+
+// ParseFilter parses a filter expression such as
+//
+//	downloads > 10k and license in (apache-2.0, mit) and lastModified within 90d and gguf
+//
+// Comparisons are =, !=, >, >=, <, <= and ~ (contains), plus "in (...)" and
+// "within <duration>" for dates. A bare word tests a boolean field (private,
+// gated) or else a tag. Terms combine with and, or, not and parentheses.
+// Numbers accept k, m, b and t suffixes; dates are YYYY-MM-DD.
+func ParseFilter(expr string) (FilterExpr, error) {
+	parsed, err := filter.Parse(expr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid filter: %w", err)
+	}
+	if err := ValidateFilter(parsed); err != nil {
+		return nil, err
+	}
+	return parsed, nil
+}
+
+// ValidateFilter checks that every field expr tests is a model field
+func ValidateFilter(expr FilterExpr) error {
+	for _, field := range expr.Fields() {
+		if _, ok := models.CanonicalField(field); !ok {
+			return fmt.Errorf("%w: %s (known: %s)", ErrUnknownField, field, strings.Join(FilterFields, ", "))
+		}
+	}
+	return nil
+}
+
+// Where starts a comparison on a model field, e.g. Where("downloads").Gt(10000)
+func Where(field string) FilterField {
+	return filter.Where(field)
+}
+
+// HasTag matches models with the given tag, e.g. HasTag("gguf")
+func HasTag(tag string) FilterExpr {
+	return filter.Tag(tag)
+}
+
+// And matches models matching every expression
+func And(exprs ...FilterExpr) FilterExpr {
+	return filter.And(exprs...)
+}
+
+// Or matches models matching at least one expression
+func Or(exprs ...FilterExpr) FilterExpr {
+	return filter.Or(exprs...)
+}
+
+// Not matches models not matching expr
+func Not(expr FilterExpr) FilterExpr {
+	return filter.Not(expr)
+}
+
+// ModelPredicate returns a function reporting whether a model matches expr,
+// for ListModelsOptions.Match
+func ModelPredicate(expr FilterExpr) func(Model) bool {
+	return func(m Model) bool {
+		return expr.Match(m)
+	}
+}
+
+// FilterModels returns opts with expr applied on the client: it is combined
+// with any Match already set, and the properties the expression needs, such
+// as cardData for the license, are added to Expand
+func FilterModels(opts ListModelsOptions, expr FilterExpr) ListModelsOptions {
+	match := ModelPredicate(expr)
+	if prev := opts.Match; prev != nil {
+		opts.Match = func(m Model) bool { return prev(m) && match(m) }
+	} else {
+		opts.Match = match
+	}
+
+	if expand := models.FieldExpand(expr.Fields()...); len(expand) > 0 {
+		opts.Expand = slices.Clone(opts.Expand)
+		for _, e := range expand {
+			if !slices.Contains(opts.Expand, e) {
+				opts.Expand = append(opts.Expand, e)
+			}
+		}
+	}
+	return opts
+}
@@ -0,0 +1,36 @@
+package hfmodels
+
+import "github.com/Megatherium/hf-go/internal/pkg/utils"
+
+// FormatTable formats models as a pretty-printed table
+func FormatTable(modelsList []Model) string {
+	return utils.FormatTable(modelsList)
+}
+
+// FormatJSON formats models as an indented JSON array
+func FormatJSON(modelsList []Model) (string, error) {
+	return utils.FormatJSON(modelsList)
+}
+
+// FormatModels formats models in the named output format: table, json,
+// ndjson, yaml, csv, tsv, markdown, or any format added with RegisterFormat
+func FormatModels(modelsList []Model, format string) (string, error) {
+	return utils.FormatModels(modelsList, format)
+}
+
+// OutputFormat is an output format of FormatModels and of the CLI
+type OutputFormat = utils.Format
+
+// OutputTable is what output formats write: headers, rows of cells and the
+// records themselves
+type OutputTable = utils.Table
+
+// RegisterFormat adds an output format, or replaces the one of that name
+func RegisterFormat(f OutputFormat) {
+	utils.RegisterFormat(f)
+}
+
+// OutputFormats returns the names of the available output formats
+func OutputFormats() []string {
+	return utils.FormatNames()
+}
@@ -0,0 +1,47 @@
+package hfmodels
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Megatherium/hf-go/internal/gguf"
+)
+
+// GGUFHeader is the parsed header of a GGUF file: all metadata key/values
+// and the tensor info table
+type GGUFHeader = gguf.Header
+
+// GGUFKeyValue is a metadata entry of a GGUF header
+type GGUFKeyValue = gguf.KeyValue
+
+// GGUFTensorInfo describes a tensor in a GGUF header
+type GGUFTensorInfo = gguf.TensorInfo
+
+// GGUFFileType is the predominant quantization of a GGUF file (general.file_type)
+type GGUFFileType = gguf.FileType
+
+// InspectGGUF reads the header of a GGUF file on the Hub with HTTP Range
+// requests, without downloading the tensor data
+func (c *Client) InspectGGUF(ctx context.Context, repoID, filename, revision string) (*GGUFHeader, error) {
+	f, err := c.openRemote(ctx, repoID, filename, revision)
+	if err != nil {
+		return nil, err
+	}
+
+	header, err := gguf.Read(f.reader())
+	if err != nil {
+		return nil, fmt.Errorf("failed to read GGUF header of %s: %w", filename, err)
+	}
+
+	return header, nil
+}
+
+// InspectGGUFFile reads the header of a local GGUF file
+func InspectGGUFFile(path string) (*GGUFHeader, error) {
+	header, err := gguf.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read GGUF header of %s: %w", path, err)
+	}
+
+	return header, nil
+}
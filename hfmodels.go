@@ -1,14 +1,15 @@
-// Package hfmodels provides a client for searching HuggingFace models
+// Package hfmodels provides a client for searching HuggingFace models.
+//
+// It is the public entry point of this module: the client, its options, the
+// model types and the output formatters are all exported from here.
 package hfmodels
 
 import (
-	"encoding/json"
-	"fmt"
-	"io"
+	"context"
 	"net/http"
-	"regexp"
+	"net/url"
+	"slices"
 	"strings"
-	"time"
 
 	"github.com/Megatherium/hf-go/internal/api"
 	"github.com/Megatherium/hf-go/internal/models"
@@ -20,119 +21,198 @@ type Model = models.Model
 // ListModelsOptions contains options for listing models
 type ListModelsOptions = models.ListModelsOptions
 
+// ModelPager lazily iterates over the pages of a model listing
+type ModelPager = api.ModelPager
+
 // ModelDetails contains detailed model information including files
-type ModelDetails struct {
-	ID           string    `json:"id"`
-	Author       string    `json:"author"`
-	Downloads    int       `json:"downloads"`
-	Likes        int       `json:"likes"`
-	LastModified time.Time `json:"lastModified"`
-	PipelineTag  string    `json:"pipeline_tag"`
-	LibraryName  string    `json:"library_name"`
-	Tags         []string  `json:"tags"`
-	Siblings     []Sibling `json:"siblings"`
-	CardData     CardData  `json:"cardData"`
-	GGUFInfo     *GGUFInfo `json:"gguf"`
-}
+type ModelDetails = models.ModelDetails
 
 // Sibling represents a file in the model repository
-type Sibling struct {
-	RFilename string `json:"rfilename"`
-}
+type Sibling = models.Sibling
+
+// SiblingLFS describes a sibling stored with Git LFS
+type SiblingLFS = models.SiblingLFS
+
+// Gated is the access control of a repository: empty if not gated, else the
+// approval mode ("auto" or "manual")
+type Gated = models.Gated
 
 // CardData contains model card metadata
-type CardData struct {
-	ModelName   string      `json:"model_name"`
-	ModelType   string      `json:"model_type"`
-	BaseModel   interface{} `json:"base_model"` // Can be string or []string
-	License     interface{} `json:"license"`    // Can be string or []string
-	QuantizedBy string      `json:"quantized_by"`
-}
-
-// GetBaseModel returns base_model as a string (first one if array)
-func (c CardData) GetBaseModel() string {
-	switch v := c.BaseModel.(type) {
-	case string:
-		return v
-	case []interface{}:
-		if len(v) > 0 {
-			if s, ok := v[0].(string); ok {
-				return s
-			}
+type CardData = models.CardData
+
+// GGUFInfo contains GGUF-specific model information
+type GGUFInfo = models.GGUFInfo
+
+// SafetensorsInfo is the parameter count of the safetensors weights as
+// computed by the Hub
+type SafetensorsInfo = models.SafetensorsInfo
+
+// APIClient is the low-level Hub client wrapped by Client. Its exported
+// fields (Endpoint, HTTPClient, Token, Retry, OnRetry) can be set directly.
+type APIClient = api.Client
+
+// NewAPIClient creates a low-level Hub client
+func NewAPIClient(token string) *APIClient {
+	return api.NewClient(token)
+}
+
+// DefaultEndpoint is the Hub used when neither WithEndpoint nor HF_ENDPOINT is set
+const DefaultEndpoint = api.DefaultEndpoint
+
+// Client is a HuggingFace API client
+type Client struct {
+	client   *api.Client
+	cacheDir string
+}
+
+// ClientOption configures a Client
+type ClientOption func(*Client)
+
+// WithEndpoint points the client at a Hub mirror or test server instead of
+// the endpoint taken from HF_ENDPOINT
+func WithEndpoint(endpoint string) ClientOption {
+	return func(c *Client) {
+		if endpoint != "" {
+			c.client.Endpoint = strings.TrimRight(endpoint, "/")
 		}
 	}
-	return ""
-}
-
-// GetLicense returns license as a string (first one if array)
-func (c CardData) GetLicense() string {
-	switch v := c.License.(type) {
-	case string:
-		return v
-	case []interface{}:
-		if len(v) > 0 {
-			if s, ok := v[0].(string); ok {
-				return s
-			}
-		}
+}
+
+// WithHTTPClient replaces the default HTTP client (30s timeout)
+func WithHTTPClient(httpClient *http.Client) ClientOption {
+	return func(c *Client) {
+		c.client.HTTPClient = httpClient
 	}
-	return ""
 }
 
-// GGUFInfo contains GGUF-specific model information
-type GGUFInfo struct {
-	Total         int64  `json:"total"`
-	Architecture  string `json:"architecture"`
-	ContextLength int    `json:"context_length"`
+// HubError is returned by every client method when the Hub answers with an
+// error status. Use errors.As to inspect it, or errors.Is with one of the
+// sentinel errors below.
+type HubError = api.HubError
+
+// Sentinel errors returned (wrapped in a HubError) by every client method
+var (
+	ErrRepoNotFound     = api.ErrRepoNotFound
+	ErrRevisionNotFound = api.ErrRevisionNotFound
+	ErrEntryNotFound    = api.ErrEntryNotFound
+	ErrGatedRepo        = api.ErrGatedRepo
+	ErrUnauthorized     = api.ErrUnauthorized
+	ErrRateLimited      = api.ErrRateLimited
+)
+
+// RetryPolicy controls how rate-limited and failed requests are retried
+type RetryPolicy = api.RetryPolicy
+
+// RetryFunc is called before a request is retried
+type RetryFunc = api.RetryFunc
+
+// DefaultRetryPolicy returns the retry policy clients start with: up to 5
+// attempts on 429 and 5xx responses, backing off from 1s
+func DefaultRetryPolicy() RetryPolicy {
+	return api.DefaultRetryPolicy()
 }
 
-// Client is a HuggingFace API client
-type Client struct {
-	client     *api.Client
-	httpClient *http.Client
-	token      string
+// WithRetryPolicy replaces the default retry policy. Pass a policy with
+// MaxAttempts set to 1 to disable retries.
+func WithRetryPolicy(policy RetryPolicy) ClientOption {
+	return func(c *Client) {
+		c.client.Retry = policy
+	}
+}
+
+// WithRetryHook registers fn to be called before every retry, e.g. for logging
+func WithRetryHook(fn RetryFunc) ClientOption {
+	return func(c *Client) {
+		c.client.OnRetry = fn
+	}
 }
 
 // NewClient creates a new HuggingFace client
-func NewClient(token string) *Client {
-	return &Client{
-		client:     api.NewClient(token),
-		httpClient: &http.Client{Timeout: 30 * time.Second},
-		token:      token,
+func NewClient(token string, opts ...ClientOption) *Client {
+	c := &Client{
+		client: api.NewClient(token),
+	}
+	for _, opt := range opts {
+		opt(c)
 	}
+	return c
+}
+
+// Endpoint returns the Hub endpoint the client sends requests to
+func (c *Client) Endpoint() string {
+	return c.client.Endpoint
 }
 
-// ListModels fetches models from HuggingFace Hub
+// ListModels fetches models from HuggingFace Hub, following pagination up to opts.Limit
 func (c *Client) ListModels(opts ListModelsOptions) ([]Model, error) {
-	return c.client.ListModels(opts)
+	return c.ListModelsContext(context.Background(), opts)
+}
+
+// ListModelsContext is like ListModels but the requests are bound to ctx
+func (c *Client) ListModelsContext(ctx context.Context, opts ListModelsOptions) ([]Model, error) {
+	return c.client.ListModelsContext(ctx, opts)
+}
+
+// ModelPages returns a lazy iterator over the pages of a model listing
+func (c *Client) ModelPages(opts ListModelsOptions) *ModelPager {
+	return c.ModelPagesContext(context.Background(), opts)
+}
+
+// ModelPagesContext is like ModelPages but the page requests are bound to ctx
+func (c *Client) ModelPagesContext(ctx context.Context, opts ListModelsOptions) *ModelPager {
+	return c.client.ModelPagesContext(ctx, opts)
 }
 
 // GetModelDetails fetches detailed information about a specific model
 func (c *Client) GetModelDetails(modelID string) (*ModelDetails, error) {
-	url := fmt.Sprintf("https://huggingface.co/api/models/%s", modelID)
+	return c.GetModelDetailsContext(context.Background(), modelID)
+}
 
-	req, err := http.NewRequest("GET", url, nil)
-	if err != nil {
-		return nil, err
-	}
+// GetModelDetailsContext is like GetModelDetails but the request is bound to ctx
+func (c *Client) GetModelDetailsContext(ctx context.Context, modelID string) (*ModelDetails, error) {
+	return c.GetModelDetailsAtRevisionContext(ctx, modelID, "")
+}
 
-	if c.token != "" {
-		req.Header.Set("Authorization", "Bearer "+c.token)
-	}
+// GetModelDetailsAtRevision fetches model information as of a branch, tag or
+// commit hash. An empty revision means the default branch.
+func (c *Client) GetModelDetailsAtRevision(modelID, revision string) (*ModelDetails, error) {
+	return c.GetModelDetailsAtRevisionContext(context.Background(), modelID, revision)
+}
 
-	resp, err := c.httpClient.Do(req)
-	if err != nil {
-		return nil, err
+// GetModelDetailsAtRevisionContext is like GetModelDetailsAtRevision but the request is bound to ctx
+func (c *Client) GetModelDetailsAtRevisionContext(ctx context.Context, modelID, revision string) (*ModelDetails, error) {
+	return c.getModelDetails(ctx, modelID, revision, false)
+}
+
+// ModelDetailsOptions selects what GetModelDetailsWithOptions fetches
+type ModelDetailsOptions struct {
+	// Revision is a branch, tag or commit hash; empty means the default branch
+	Revision string
+	// Blobs adds the size, blob ID and LFS information of every sibling
+	Blobs bool
+}
+
+// GetModelDetailsWithOptions fetches model information at a revision,
+// optionally with the size of every file
+func (c *Client) GetModelDetailsWithOptions(ctx context.Context, modelID string, opts ModelDetailsOptions) (*ModelDetails, error) {
+	return c.getModelDetails(ctx, modelID, opts.Revision, opts.Blobs)
+}
+
+// getModelDetails fetches model information, with the size, blob ID and LFS
+// information of every sibling if blobs is set
+func (c *Client) getModelDetails(ctx context.Context, modelID, revision string, blobs bool) (*ModelDetails, error) {
+	path := "/api/models/" + modelID
+	if revision != "" {
+		path += "/revision/" + url.PathEscape(revision)
 	}
-	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("API error %d: %s", resp.StatusCode, string(body))
+	params := url.Values{}
+	if blobs {
+		params.Set("blobs", "true")
 	}
 
 	var details ModelDetails
-	if err := json.NewDecoder(resp.Body).Decode(&details); err != nil {
+	if err := c.client.GetJSON(ctx, path, params, &details); err != nil {
 		return nil, err
 	}
 
@@ -141,82 +221,49 @@ func (c *Client) GetModelDetails(modelID string) (*ModelDetails, error) {
 
 // GetAvailableQuants returns the available quantizations for a GGUF model
 func (c *Client) GetAvailableQuants(modelID string) ([]string, error) {
-	details, err := c.GetModelDetails(modelID)
+	return c.GetAvailableQuantsContext(context.Background(), modelID)
+}
+
+// GetAvailableQuantsContext is like GetAvailableQuants but the request is bound to ctx
+func (c *Client) GetAvailableQuantsContext(ctx context.Context, modelID string) ([]string, error) {
+	variants, err := c.GetQuantVariantsContext(ctx, modelID, "")
 	if err != nil {
 		return nil, err
 	}
 
-	return ExtractQuantsFromSiblings(details.Siblings), nil
+	return quantNames(variants), nil
 }
 
-// ExtractQuantsFromSiblings parses GGUF filenames to extract quantization types
-func ExtractQuantsFromSiblings(siblings []Sibling) []string {
-	// Match common quant patterns - handles both filename and path formats
-	// Patterns: Q4_K_M, IQ4_NL, F16, BF16, TQ1_0, UD-TQ1_0, etc. (case insensitive)
-	quantPatterns := []*regexp.Regexp{
-		// Standard quants in filename: model-Q4_K_M.gguf or model.Q4_K_M.gguf
-		regexp.MustCompile(`(?i)[._-](Q[0-9]+_[A-Z0-9_]+)\.gguf`),
-		regexp.MustCompile(`(?i)[._-](IQ[0-9]+_[A-Z0-9_]+)\.gguf`),
-		regexp.MustCompile(`(?i)[._-](F16|F32|BF16)\.gguf`),
-		// Unsloth style: model-UD-TQ1_0.gguf
-		regexp.MustCompile(`(?i)[._-]((?:UD-)?TQ[0-9]+_[0-9]+)\.gguf`),
-		// Split files pattern: model-Q4_K_M-00001-of-00005.gguf
-		regexp.MustCompile(`(?i)[._-](Q[0-9]+_[A-Z0-9_]+)-\d+-of-\d+\.gguf`),
-		regexp.MustCompile(`(?i)[._-](IQ[0-9]+_[A-Z0-9_]+)-\d+-of-\d+\.gguf`),
-		regexp.MustCompile(`(?i)[._-](F16|F32|BF16)-\d+-of-\d+\.gguf`),
-	}
-
-	// Pattern for directory-based quants: BF16/model-BF16-00001-of-00005.gguf
-	dirQuantPattern := regexp.MustCompile(`(?i)^([A-Z0-9_]+)/`)
-
-	seen := make(map[string]bool)
-	var quants []string
-
-	for _, s := range siblings {
-		filename := s.RFilename
-		if !strings.HasSuffix(strings.ToLower(filename), ".gguf") {
-			continue
-		}
-
-		var quant string
-
-		// First check if it's in a quant-named directory
-		if dirMatch := dirQuantPattern.FindStringSubmatch(filename); len(dirMatch) > 1 {
-			potentialQuant := dirMatch[1]
-			// Verify it looks like a quant name (not just any directory)
-			if isQuantName(potentialQuant) {
-				quant = potentialQuant
-			}
-		}
-
-		// If not found in directory, try filename patterns
-		if quant == "" {
-			for _, pattern := range quantPatterns {
-				matches := pattern.FindStringSubmatch(filename)
-				if len(matches) > 1 {
-					quant = strings.ToUpper(matches[1])
-					break
-				}
-			}
-		}
+// GetQuantVariants returns the quant variants of a GGUF model with the real
+// size of their files
+func (c *Client) GetQuantVariants(modelID, revision string) ([]QuantVariant, error) {
+	return c.GetQuantVariantsContext(context.Background(), modelID, revision)
+}
 
-		if quant != "" && !seen[quant] {
-			seen[quant] = true
-			quants = append(quants, quant)
-		}
+// GetQuantVariantsContext is like GetQuantVariants but the request is bound to ctx
+func (c *Client) GetQuantVariantsContext(ctx context.Context, modelID, revision string) ([]QuantVariant, error) {
+	details, err := c.getModelDetails(ctx, modelID, revision, true)
+	if err != nil {
+		return nil, err
 	}
 
-	return quants
+	return GroupQuantVariants(details.Siblings), nil
 }
 
-// isQuantName checks if a string looks like a quantization name
-func isQuantName(s string) bool {
-	quantPrefixes := []string{"Q", "IQ", "F16", "F32", "BF16", "TQ"}
-	upper := strings.ToUpper(s)
-	for _, prefix := range quantPrefixes {
-		if strings.HasPrefix(upper, prefix) {
-			return true
+// ExtractQuantsFromSiblings parses GGUF filenames to extract quantization types.
+// It returns the names of the main weight variants found by GroupQuantVariants,
+// leaving out projectors, LoRA adapters and draft models.
+func ExtractQuantsFromSiblings(siblings []Sibling) []string {
+	return quantNames(GroupQuantVariants(siblings))
+}
+
+// quantNames returns the names of the variants holding main model weights
+func quantNames(variants []QuantVariant) []string {
+	var quants []string
+	for _, v := range ModelVariants(variants) {
+		if !slices.Contains(quants, v.Name) {
+			quants = append(quants, v.Name)
 		}
 	}
-	return false
+	return quants
 }
@@ -1,11 +1,14 @@
 package api
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
 	"net/url"
+	"os"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
@@ -14,42 +17,199 @@ import (
 )
 
 const (
-	DefaultAPIURL = "https://huggingface.co/api/models"
+	// DefaultEndpoint is the Hub used when neither Client.Endpoint nor HF_ENDPOINT is set
+	DefaultEndpoint = "https://huggingface.co"
+
+	// EndpointEnv is the environment variable overriding the Hub endpoint,
+	// shared with the Python huggingface_hub library
+	EndpointEnv = "HF_ENDPOINT"
 )
 
 // Client represents a Hugging Face API client
 type Client struct {
-	BaseURL    string
+	// Endpoint is the base URL of the Hub (or a mirror) every request path is built from
+	Endpoint   string
 	HTTPClient *http.Client
 	Token      string
+	// Retry controls how rate-limited and failed requests are retried
+	Retry RetryPolicy
+	// OnRetry, if set, is called before every retry
+	OnRetry RetryFunc
 }
 
 // NewClient creates a new Hugging Face API client
 func NewClient(token string) *Client {
 	return &Client{
-		BaseURL: DefaultAPIURL,
+		Endpoint: EndpointFromEnv(),
 		HTTPClient: &http.Client{
 			Timeout: 30 * time.Second,
 		},
 		Token: token,
+		Retry: DefaultRetryPolicy(),
+	}
+}
+
+// EndpointFromEnv returns the endpoint set in HF_ENDPOINT, or DefaultEndpoint
+func EndpointFromEnv() string {
+	if endpoint := strings.TrimSpace(os.Getenv(EndpointEnv)); endpoint != "" {
+		return strings.TrimRight(endpoint, "/")
+	}
+	return DefaultEndpoint
+}
+
+// URL joins path onto the client's endpoint
+func (c *Client) URL(path string) string {
+	endpoint := c.Endpoint
+	if endpoint == "" {
+		endpoint = DefaultEndpoint
+	}
+	return strings.TrimRight(endpoint, "/") + "/" + strings.TrimLeft(path, "/")
+}
+
+// GetJSON issues a GET request for path on the client's endpoint and decodes
+// the JSON response into v
+func (c *Client) GetJSON(ctx context.Context, path string, params url.Values, v interface{}) error {
+	reqURL := c.URL(path)
+	if len(params) > 0 {
+		reqURL = fmt.Sprintf("%s?%s", reqURL, params.Encode())
+	}
+
+	req, err := c.newRequest(ctx, "GET", reqURL, "")
+	if err != nil {
+		return err
+	}
+
+	resp, err := c.do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if err := json.NewDecoder(resp.Body).Decode(v); err != nil {
+		return fmt.Errorf("failed to parse response: %w", err)
 	}
+
+	return nil
+}
+
+// newRequest creates a request authorized with token, falling back to the client's token
+func (c *Client) newRequest(ctx context.Context, method, reqURL, token string) (*http.Request, error) {
+	req, err := http.NewRequestWithContext(ctx, method, reqURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	// Add authorization header if token is provided
+	if token == "" {
+		token = c.Token
+	}
+	if token != "" {
+		req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	}
+
+	return req, nil
+}
+
+// do executes req and returns the response if the Hub answered with 200 OK,
+// retrying according to the client's retry policy. The caller is responsible
+// for closing the response body.
+func (c *Client) do(req *http.Request) (*http.Response, error) {
+	return c.send(c.HTTPClient, req, http.StatusOK)
+}
+
+// send executes req with httpClient and returns the response if its status is
+// one of accepted, retrying according to the client's retry policy
+func (c *Client) send(httpClient *http.Client, req *http.Request, accepted ...int) (*http.Response, error) {
+	for attempt := 1; ; attempt++ {
+		resp, err := httpClient.Do(req)
+		if err != nil {
+			err = fmt.Errorf("failed to execute request: %w", err)
+			// Don't retry once the caller gave up
+			if req.Context().Err() != nil {
+				return nil, err
+			}
+			if !c.waitRetry(req, attempt, c.Retry.backoff(attempt), err) {
+				return nil, err
+			}
+			continue
+		}
+
+		if slices.Contains(accepted, resp.StatusCode) {
+			return resp, nil
+		}
+
+		body, _ := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		err = newHubError(resp, body)
+		if !c.Retry.retryable(resp.StatusCode) {
+			return nil, err
+		}
+
+		delay := c.Retry.backoff(attempt)
+		if d, ok := serverDelay(resp); ok {
+			if c.Retry.MaxDelay > 0 && d > c.Retry.MaxDelay {
+				return nil, err
+			}
+			delay = d
+		}
+		if !c.waitRetry(req, attempt, delay, err) {
+			return nil, err
+		}
+	}
+}
+
+// waitRetry sleeps before retrying req and reports whether another attempt should be made
+func (c *Client) waitRetry(req *http.Request, attempt int, delay time.Duration, err error) bool {
+	if attempt >= c.Retry.MaxAttempts {
+		return false
+	}
+	if c.OnRetry != nil {
+		c.OnRetry(req, attempt, delay, err)
+	}
+	return sleep(req.Context(), delay) == nil
 }
 
 // apiModel represents the raw model response from the API
 type apiModel struct {
-	ID            string    `json:"id"`
-	Downloads     int       `json:"downloads"`
-	Likes         int       `json:"likes"`
-	LastModified  time.Time `json:"lastModified"`
-	LibraryName   string    `json:"library_name"`
-	PipelineTag   string    `json:"pipeline_tag"`
-	Private       bool      `json:"private"`
+	ID            string      `json:"id"`
+	Downloads     int         `json:"downloads"`
+	Likes         int         `json:"likes"`
+	LastModified  time.Time   `json:"lastModified"`
+	LibraryName   string      `json:"library_name"`
+	PipelineTag   string      `json:"pipeline_tag"`
+	Private       bool        `json:"private"`
 	Gated         interface{} `json:"gated"`
-	TrendingScore float64   `json:"trendingScore"`
+	TrendingScore float64     `json:"trendingScore"`
+
+	Author           string                  `json:"author"`
+	Tags             []string                `json:"tags"`
+	CreatedAt        time.Time               `json:"createdAt"`
+	SHA              string                  `json:"sha"`
+	DownloadsAllTime int                     `json:"downloadsAllTime"`
+	Siblings         []models.Sibling        `json:"siblings"`
+	CardData         *models.CardData        `json:"cardData"`
+	Safetensors      *models.SafetensorsInfo `json:"safetensors"`
+	GGUF             *models.GGUFInfo        `json:"gguf"`
 }
 
-// ListModels fetches models from the Hugging Face Hub based on the provided options
+// ListModels fetches models from the Hugging Face Hub based on the provided options.
+// It follows the Hub's pagination until opts.Limit models have been collected, or
+// until the last page when no limit is set.
 func (c *Client) ListModels(opts models.ListModelsOptions) ([]models.Model, error) {
+	return c.ListModelsContext(context.Background(), opts)
+}
+
+// ListModelsContext is like ListModels but carries ctx into every page request
+func (c *Client) ListModelsContext(ctx context.Context, opts models.ListModelsOptions) ([]models.Model, error) {
+	return c.ModelPagesContext(ctx, opts).All()
+}
+
+// matchPageSize is the minimum page size requested when models are filtered
+// on the client
+const matchPageSize = 100
+
+// listModelsURL builds the URL of the first page of a model listing
+func (c *Client) listModelsURL(opts models.ListModelsOptions) string {
 	// Build query parameters
 	params := url.Values{}
 
@@ -59,6 +219,9 @@ func (c *Client) ListModels(opts models.ListModelsOptions) ([]models.Model, erro
 	if opts.Filter != "" {
 		params.Add("filter", opts.Filter)
 	}
+	for _, filter := range opts.ServerFilters() {
+		params.Add("filter", filter)
+	}
 	if opts.Author != "" {
 		params.Add("author", opts.Author)
 	}
@@ -74,8 +237,13 @@ func (c *Client) ListModels(opts models.ListModelsOptions) ([]models.Model, erro
 	if opts.Tag != "" {
 		params.Add("tags", opts.Tag)
 	}
-	if opts.Limit > 0 {
-		params.Add("limit", strconv.Itoa(opts.Limit))
+	if limit := opts.Limit; limit > 0 {
+		// Most models of a page may be filtered out on the client, so fetch
+		// larger pages rather than many small ones
+		if opts.ClientMatch() != nil && limit < matchPageSize {
+			limit = matchPageSize
+		}
+		params.Add("limit", strconv.Itoa(limit))
 	}
 	if opts.Sort != "" {
 		params.Add("sort", opts.Sort)
@@ -83,84 +251,72 @@ func (c *Client) ListModels(opts models.ListModelsOptions) ([]models.Model, erro
 	if opts.Direction != 0 {
 		params.Add("direction", strconv.Itoa(opts.Direction))
 	}
-
-	// Build request URL
-	reqURL := c.BaseURL
-	if len(params) > 0 {
-		reqURL = fmt.Sprintf("%s?%s", c.BaseURL, params.Encode())
-	}
-
-	// Create request
-	req, err := http.NewRequest("GET", reqURL, nil)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
-	}
-
-	// Add authorization header if token is provided
-	token := opts.Token
-	if token == "" {
-		token = c.Token
-	}
-	if token != "" {
-		req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	for _, field := range opts.ExpandFields() {
+		params.Add("expand[]", field)
 	}
 
-	// Execute request
-	resp, err := c.HTTPClient.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("failed to execute request: %w", err)
-	}
-	defer resp.Body.Close()
-
-	// Check status code
-	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("API request failed with status %d: %s", resp.StatusCode, string(body))
+	// Build request URL
+	if len(params) == 0 {
+		return c.URL("/api/models")
 	}
+	return fmt.Sprintf("%s?%s", c.URL("/api/models"), params.Encode())
+}
 
-	// Parse response
-	body, err := io.ReadAll(resp.Body)
+// fetchModelsPage fetches a single page of models and returns the URL of the next page,
+// or an empty string if this was the last one
+func (c *Client) fetchModelsPage(ctx context.Context, reqURL, token string) ([]models.Model, string, error) {
+	apiModels, next, err := fetchJSONPage[apiModel](ctx, c, reqURL, token)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read response body: %w", err)
-	}
-
-	var apiModels []apiModel
-	if err := json.Unmarshal(body, &apiModels); err != nil {
-		return nil, fmt.Errorf("failed to parse response: %w", err)
+		return nil, "", err
 	}
+	return convertModels(apiModels), next, nil
+}
 
-	// Convert to internal model format
+// convertModels converts raw API models to the internal model format
+func convertModels(apiModels []apiModel) []models.Model {
 	result := make([]models.Model, len(apiModels))
 	for i, am := range apiModels {
-		author := ""
-		if strings.Contains(am.ID, "/") {
-			parts := strings.SplitN(am.ID, "/", 2)
-			author = parts[0]
-		}
-
-		gated := false
-		if am.Gated != nil {
-			switch v := am.Gated.(type) {
-			case bool:
-				gated = v
-			case string:
-				gated = v != "" && v != "false"
-			}
-		}
-
 		result[i] = models.Model{
 			ID:            am.ID,
-			Author:        author,
+			Author:        RepoAuthor(am.Author, am.ID),
 			Downloads:     am.Downloads,
 			Likes:         am.Likes,
 			LastModified:  am.LastModified,
 			LibraryName:   am.LibraryName,
 			PipelineTag:   am.PipelineTag,
 			Private:       am.Private,
-			Gated:         gated,
+			Gated:         isGated(am.Gated),
 			TrendingScore: am.TrendingScore,
+
+			Tags:             am.Tags,
+			CreatedAt:        am.CreatedAt,
+			SHA:              am.SHA,
+			DownloadsAllTime: am.DownloadsAllTime,
+			Siblings:         am.Siblings,
+			CardData:         am.CardData,
+			Safetensors:      am.Safetensors,
+			GGUF:             am.GGUF,
 		}
 	}
 
-	return result, nil
+	return result
+}
+
+// RepoAuthor returns author, or the namespace of the repository ID if empty
+func RepoAuthor(author, id string) string {
+	if author == "" && strings.Contains(id, "/") {
+		author, _, _ = strings.Cut(id, "/")
+	}
+	return author
+}
+
+// isGated converts the boolean or string "gated" property of a listing
+func isGated(v interface{}) bool {
+	switch v := v.(type) {
+	case bool:
+		return v
+	case string:
+		return v != "" && v != "false"
+	}
+	return false
 }
@@ -0,0 +1,114 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/Megatherium/hf-go/internal/models"
+)
+
+// apiDataset represents the raw dataset response from the API
+type apiDataset struct {
+	ID            string      `json:"id"`
+	Author        string      `json:"author"`
+	Downloads     int         `json:"downloads"`
+	Likes         int         `json:"likes"`
+	LastModified  time.Time   `json:"lastModified"`
+	CreatedAt     time.Time   `json:"createdAt"`
+	Private       bool        `json:"private"`
+	Gated         interface{} `json:"gated"`
+	TrendingScore float64     `json:"trendingScore"`
+	Tags          []string    `json:"tags"`
+	Description   string      `json:"description"`
+	SHA           string      `json:"sha"`
+}
+
+// DatasetPager lazily walks the pages of a dataset listing
+type DatasetPager = Pager[models.Dataset]
+
+// ListDatasets fetches datasets from the Hugging Face Hub, following the
+// pagination until opts.Limit datasets have been collected
+func (c *Client) ListDatasets(opts models.ListDatasetsOptions) ([]models.Dataset, error) {
+	return c.ListDatasetsContext(context.Background(), opts)
+}
+
+// ListDatasetsContext is like ListDatasets but carries ctx into every page request
+func (c *Client) ListDatasetsContext(ctx context.Context, opts models.ListDatasetsOptions) ([]models.Dataset, error) {
+	return c.DatasetPagesContext(ctx, opts).All()
+}
+
+// DatasetPages returns a pager over the datasets matching opts
+func (c *Client) DatasetPages(opts models.ListDatasetsOptions) *DatasetPager {
+	return c.DatasetPagesContext(context.Background(), opts)
+}
+
+// DatasetPagesContext is like DatasetPages but carries ctx into every page request
+func (c *Client) DatasetPagesContext(ctx context.Context, opts models.ListDatasetsOptions) *DatasetPager {
+	return newPager(ctx, c.listDatasetsURL(opts), opts.Token, opts.Limit, opts.Match, c.fetchDatasetsPage)
+}
+
+// listDatasetsURL builds the URL of the first page of a dataset listing
+func (c *Client) listDatasetsURL(opts models.ListDatasetsOptions) string {
+	params := url.Values{}
+
+	if opts.Search != "" {
+		params.Add("search", opts.Search)
+	}
+	if opts.Author != "" {
+		params.Add("author", opts.Author)
+	}
+	for _, filter := range opts.ServerFilters() {
+		params.Add("filter", filter)
+	}
+	if limit := opts.Limit; limit > 0 {
+		if opts.Match != nil && limit < matchPageSize {
+			limit = matchPageSize
+		}
+		params.Add("limit", strconv.Itoa(limit))
+	}
+	if opts.Sort != "" {
+		params.Add("sort", opts.Sort)
+	}
+	if opts.Direction != 0 {
+		params.Add("direction", strconv.Itoa(opts.Direction))
+	}
+	if opts.Full {
+		params.Add("full", "true")
+	}
+
+	if len(params) == 0 {
+		return c.URL("/api/datasets")
+	}
+	return fmt.Sprintf("%s?%s", c.URL("/api/datasets"), params.Encode())
+}
+
+// fetchDatasetsPage fetches a single page of datasets and returns the URL of
+// the next page, or an empty string if this was the last one
+func (c *Client) fetchDatasetsPage(ctx context.Context, reqURL, token string) ([]models.Dataset, string, error) {
+	apiDatasets, next, err := fetchJSONPage[apiDataset](ctx, c, reqURL, token)
+	if err != nil {
+		return nil, "", err
+	}
+
+	result := make([]models.Dataset, len(apiDatasets))
+	for i, ad := range apiDatasets {
+		result[i] = models.Dataset{
+			ID:            ad.ID,
+			Author:        RepoAuthor(ad.Author, ad.ID),
+			Downloads:     ad.Downloads,
+			Likes:         ad.Likes,
+			LastModified:  ad.LastModified,
+			CreatedAt:     ad.CreatedAt,
+			Private:       ad.Private,
+			Gated:         isGated(ad.Gated),
+			TrendingScore: ad.TrendingScore,
+			Tags:          ad.Tags,
+			Description:   ad.Description,
+			SHA:           ad.SHA,
+		}
+	}
+	return result, next, nil
+}
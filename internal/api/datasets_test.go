@@ -0,0 +1,60 @@
+package api
+
+import (
+	"net/url"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/Megatherium/hf-go/internal/models"
+)
+
+func TestListDatasetsURL(t *testing.T) {
+	match := func(models.Dataset) bool { return true }
+
+	tests := []struct {
+		name string
+		opts models.ListDatasetsOptions
+		want url.Values
+	}{
+		{"no options", models.ListDatasetsOptions{}, url.Values{}},
+		{"search and author", models.ListDatasetsOptions{Search: "squad", Author: "rajpurkar"},
+			url.Values{"search": {"squad"}, "author": {"rajpurkar"}}},
+		{"prefixed filters", models.ListDatasetsOptions{
+			Filters:        []string{"modality:text"},
+			Tags:           []string{"synthetic"},
+			TaskCategories: []string{"text-classification"},
+			SizeCategories: []string{"10K<n<100K"},
+			Languages:      []string{"en", "fr"},
+		}, url.Values{"filter": {
+			"modality:text", "synthetic", "task_categories:text-classification",
+			"size_categories:10K<n<100K", "language:en", "language:fr",
+		}}},
+		{"full", models.ListDatasetsOptions{Full: true}, url.Values{"full": {"true"}}},
+		{"sort", models.ListDatasetsOptions{Sort: "downloads", Direction: -1},
+			url.Values{"sort": {"downloads"}, "direction": {"-1"}}},
+		{"limit", models.ListDatasetsOptions{Limit: 10}, url.Values{"limit": {"10"}}},
+		// Matching on the client skips datasets, so pages are fetched larger
+		{"limit with match", models.ListDatasetsOptions{Limit: 10, Match: match}, url.Values{"limit": {"100"}}},
+		{"large limit with match", models.ListDatasetsOptions{Limit: 500, Match: match}, url.Values{"limit": {"500"}}},
+		{"no limit with match", models.ListDatasetsOptions{Match: match}, url.Values{}},
+	}
+
+	c := &Client{Endpoint: "https://hub.test/"}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := c.listDatasetsURL(tt.opts)
+			base, query, _ := strings.Cut(got, "?")
+			if base != "https://hub.test/api/datasets" {
+				t.Errorf("URL = %s, want the /api/datasets endpoint", got)
+			}
+			params, err := url.ParseQuery(query)
+			if err != nil {
+				t.Fatalf("invalid query %q: %v", query, err)
+			}
+			if !reflect.DeepEqual(params, tt.want) {
+				t.Errorf("query = %v, want %v", params, tt.want)
+			}
+		})
+	}
+}
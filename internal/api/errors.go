@@ -0,0 +1,110 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// Sentinel errors matched by HubError through errors.Is
+var (
+	ErrRepoNotFound     = errors.New("repository not found")
+	ErrRevisionNotFound = errors.New("revision not found")
+	ErrEntryNotFound    = errors.New("entry not found")
+	ErrGatedRepo        = errors.New("repository is gated")
+	ErrUnauthorized     = errors.New("unauthorized")
+	ErrRateLimited      = errors.New("rate limited")
+)
+
+// HubError is returned when the Hub answers a request with an error status
+type HubError struct {
+	// StatusCode is the HTTP status of the response
+	StatusCode int
+	// RequestID is the X-Request-Id header, useful when reporting issues to Hugging Face
+	RequestID string
+	// ErrorCode is the X-Error-Code header, e.g. "RepoNotFound" or "GatedRepo"
+	ErrorCode string
+	// ErrorMessage is the X-Error-Message header, or the error from the response body
+	ErrorMessage string
+	// URL is the requested URL
+	URL string
+
+	kind error
+}
+
+// newHubError builds a HubError from an error response and its body
+func newHubError(resp *http.Response, body []byte) *HubError {
+	e := &HubError{
+		StatusCode:   resp.StatusCode,
+		RequestID:    resp.Header.Get("X-Request-Id"),
+		ErrorCode:    resp.Header.Get("X-Error-Code"),
+		ErrorMessage: resp.Header.Get("X-Error-Message"),
+	}
+	if resp.Request != nil {
+		e.URL = resp.Request.URL.String()
+	}
+
+	// Fall back to the body, which is usually {"error": "..."}
+	if e.ErrorMessage == "" {
+		var payload struct {
+			Error string `json:"error"`
+		}
+		if json.Unmarshal(body, &payload) == nil && payload.Error != "" {
+			e.ErrorMessage = payload.Error
+		} else {
+			e.ErrorMessage = strings.TrimSpace(string(body))
+		}
+	}
+
+	e.kind = classify(e.StatusCode, e.ErrorCode)
+	return e
+}
+
+// classify maps a status and X-Error-Code to one of the sentinel errors
+func classify(status int, code string) error {
+	switch code {
+	case "RepoNotFound":
+		return ErrRepoNotFound
+	case "RevisionNotFound":
+		return ErrRevisionNotFound
+	case "EntryNotFound":
+		return ErrEntryNotFound
+	case "GatedRepo":
+		return ErrGatedRepo
+	}
+
+	switch status {
+	case http.StatusUnauthorized, http.StatusForbidden:
+		return ErrUnauthorized
+	case http.StatusNotFound:
+		return ErrRepoNotFound
+	case http.StatusTooManyRequests:
+		return ErrRateLimited
+	}
+
+	return nil
+}
+
+// Error implements the error interface
+func (e *HubError) Error() string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "API request failed with status %d", e.StatusCode)
+	if e.ErrorCode != "" {
+		fmt.Fprintf(&sb, " (%s)", e.ErrorCode)
+	}
+	if e.ErrorMessage != "" {
+		fmt.Fprintf(&sb, ": %s", e.ErrorMessage)
+	}
+	if e.RequestID != "" {
+		fmt.Fprintf(&sb, " [request ID: %s]", e.RequestID)
+	}
+	return sb.String()
+}
+
+// Unwrap returns the sentinel error matching the response, so that
+// errors.Is(err, ErrRepoNotFound) and friends work
+func (e *HubError) Unwrap() error {
+	return e.kind
+}
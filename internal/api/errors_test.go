@@ -0,0 +1,117 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"net/url"
+	"testing"
+)
+
+func TestClassify(t *testing.T) {
+	tests := []struct {
+		status int
+		code   string
+		want   error
+	}{
+		// The Hub answers 401 rather than 404 for missing repositories, so
+		// that private ones can't be probed; the error code tells them apart
+		{http.StatusUnauthorized, "RepoNotFound", ErrRepoNotFound},
+		{http.StatusUnauthorized, "", ErrUnauthorized},
+		{http.StatusForbidden, "GatedRepo", ErrGatedRepo},
+		{http.StatusUnauthorized, "GatedRepo", ErrGatedRepo},
+		{http.StatusForbidden, "", ErrUnauthorized},
+		{http.StatusNotFound, "RepoNotFound", ErrRepoNotFound},
+		{http.StatusNotFound, "RevisionNotFound", ErrRevisionNotFound},
+		{http.StatusNotFound, "EntryNotFound", ErrEntryNotFound},
+		{http.StatusNotFound, "", ErrRepoNotFound},
+		{http.StatusTooManyRequests, "", ErrRateLimited},
+		{http.StatusBadRequest, "", nil},
+		{http.StatusInternalServerError, "", nil},
+		{http.StatusBadRequest, "SomethingNew", nil},
+	}
+	for _, tt := range tests {
+		if got := classify(tt.status, tt.code); got != tt.want {
+			t.Errorf("classify(%d, %q) = %v, want %v", tt.status, tt.code, got, tt.want)
+		}
+	}
+}
+
+func TestNewHubError(t *testing.T) {
+	reqURL, _ := url.Parse("https://huggingface.co/api/models/org/model")
+
+	tests := []struct {
+		name    string
+		status  int
+		header  map[string]string
+		body    string
+		want    HubError
+		kind    error
+		message string
+	}{
+		{
+			name:   "headers",
+			status: http.StatusNotFound,
+			header: map[string]string{"X-Error-Code": "RevisionNotFound", "X-Error-Message": "Invalid rev id: dev", "X-Request-Id": "Root=1-abc"},
+			body:   `{"error":"ignored"}`,
+			want: HubError{StatusCode: 404, ErrorCode: "RevisionNotFound", ErrorMessage: "Invalid rev id: dev",
+				RequestID: "Root=1-abc", URL: reqURL.String()},
+			kind:    ErrRevisionNotFound,
+			message: "API request failed with status 404 (RevisionNotFound): Invalid rev id: dev [request ID: Root=1-abc]",
+		},
+		{
+			name:    "gated",
+			status:  http.StatusForbidden,
+			header:  map[string]string{"X-Error-Code": "GatedRepo"},
+			body:    `{"error":"Access to model org/model is restricted."}`,
+			want:    HubError{StatusCode: 403, ErrorCode: "GatedRepo", ErrorMessage: "Access to model org/model is restricted.", URL: reqURL.String()},
+			kind:    ErrGatedRepo,
+			message: "API request failed with status 403 (GatedRepo): Access to model org/model is restricted.",
+		},
+		{
+			name:    "JSON body",
+			status:  http.StatusUnauthorized,
+			body:    `{"error":"Invalid credentials in Authorization header"}`,
+			want:    HubError{StatusCode: 401, ErrorMessage: "Invalid credentials in Authorization header", URL: reqURL.String()},
+			kind:    ErrUnauthorized,
+			message: "API request failed with status 401: Invalid credentials in Authorization header",
+		},
+		{
+			name:    "plain body",
+			status:  http.StatusBadGateway,
+			body:    "  Bad Gateway\n",
+			want:    HubError{StatusCode: 502, ErrorMessage: "Bad Gateway", URL: reqURL.String()},
+			message: "API request failed with status 502: Bad Gateway",
+		},
+		{
+			name:    "no body",
+			status:  http.StatusTooManyRequests,
+			want:    HubError{StatusCode: 429, URL: reqURL.String()},
+			kind:    ErrRateLimited,
+			message: "API request failed with status 429",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := &http.Response{StatusCode: tt.status, Header: http.Header{}, Request: &http.Request{URL: reqURL}}
+			for k, v := range tt.header {
+				resp.Header.Set(k, v)
+			}
+
+			err := newHubError(resp, []byte(tt.body))
+			got := *err
+			got.kind = nil
+			if got != tt.want {
+				t.Errorf("newHubError = %+v, want %+v", got, tt.want)
+			}
+			if err.Error() != tt.message {
+				t.Errorf("Error() = %q, want %q", err.Error(), tt.message)
+			}
+			if tt.kind != nil && !errors.Is(err, tt.kind) {
+				t.Errorf("errors.Is(err, %v) = false", tt.kind)
+			}
+			if tt.kind == nil && errors.Unwrap(err) != nil {
+				t.Errorf("Unwrap() = %v, want nil", errors.Unwrap(err))
+			}
+		})
+	}
+}
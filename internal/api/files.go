@@ -0,0 +1,128 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// FileMetadata describes a repository file as reported by the resolve endpoint
+type FileMetadata struct {
+	// CommitHash is the commit the revision resolved to
+	CommitHash string
+	// ETag is the SHA256 of LFS files, or the git blob ID of regular files
+	ETag string
+	// Size is the file size in bytes, or -1 if unknown
+	Size int64
+	// Location is the URL the file contents are served from
+	Location string
+}
+
+// ResolveURL returns the URL serving filename at revision in a model repository
+func (c *Client) ResolveURL(repoID, revision, filename string) string {
+	segments := strings.Split(filename, "/")
+	for i, s := range segments {
+		segments[i] = url.PathEscape(s)
+	}
+	return c.URL(fmt.Sprintf("/%s/resolve/%s/%s", repoID, url.PathEscape(revision), strings.Join(segments, "/")))
+}
+
+// GetFileMetadata issues a HEAD request for a resolve URL. Relative redirects
+// (e.g. renamed repositories) are followed, while the redirect to the storage
+// backend is only recorded in Location.
+func (c *Client) GetFileMetadata(ctx context.Context, resolveURL string) (*FileMetadata, error) {
+	noRedirect := *c.HTTPClient
+	noRedirect.CheckRedirect = func(req *http.Request, via []*http.Request) error {
+		return http.ErrUseLastResponse
+	}
+
+	reqURL := resolveURL
+	for redirects := 0; ; redirects++ {
+		req, err := c.newRequest(ctx, "HEAD", reqURL, "")
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("Accept-Encoding", "identity")
+
+		resp, err := c.send(&noRedirect, req, http.StatusOK,
+			http.StatusMovedPermanently, http.StatusFound, http.StatusSeeOther,
+			http.StatusTemporaryRedirect, http.StatusPermanentRedirect)
+		if err != nil {
+			return nil, err
+		}
+		resp.Body.Close()
+
+		location := ""
+		if loc := resp.Header.Get("Location"); loc != "" {
+			ref, err := url.Parse(loc)
+			if err != nil {
+				return nil, fmt.Errorf("invalid redirect location %q: %w", loc, err)
+			}
+			location = req.URL.ResolveReference(ref).String()
+
+			// Relative redirects stay on the Hub (e.g. renamed repositories)
+			// and the metadata is on the final one
+			if !ref.IsAbs() && redirects < 10 {
+				reqURL = location
+				continue
+			}
+		}
+
+		meta := &FileMetadata{
+			CommitHash: resp.Header.Get("X-Repo-Commit"),
+			ETag:       normalizeETag(firstHeader(resp.Header, "X-Linked-Etag", "ETag")),
+			Size:       -1,
+			Location:   location,
+		}
+		if meta.Location == "" {
+			meta.Location = reqURL
+		}
+		if size, err := strconv.ParseInt(firstHeader(resp.Header, "X-Linked-Size", "Content-Length"), 10, 64); err == nil {
+			meta.Size = size
+		}
+
+		return meta, nil
+	}
+}
+
+// Fetch issues a GET request for a file URL and returns the response if its
+// status is 200 or 206. Unlike API calls it is not subject to the HTTP
+// client's timeout, so large bodies can be streamed until ctx is done. The
+// token is only sent to the Hub itself, not to the storage backend.
+func (c *Client) Fetch(ctx context.Context, fileURL string, header http.Header) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", fileURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	for key, values := range header {
+		req.Header[key] = values
+	}
+	req.Header.Set("Accept-Encoding", "identity")
+
+	if endpoint, err := url.Parse(c.URL("/")); err == nil && endpoint.Host == req.URL.Host && c.Token != "" {
+		req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.Token))
+	}
+
+	noTimeout := *c.HTTPClient
+	noTimeout.Timeout = 0
+
+	return c.send(&noTimeout, req, http.StatusOK, http.StatusPartialContent)
+}
+
+// firstHeader returns the first non-empty value among the given headers
+func firstHeader(header http.Header, keys ...string) string {
+	for _, key := range keys {
+		if value := header.Get(key); value != "" {
+			return value
+		}
+	}
+	return ""
+}
+
+// normalizeETag strips the weak validator prefix and quotes from an ETag
+func normalizeETag(etag string) string {
+	return strings.Trim(strings.TrimPrefix(etag, "W/"), `"`)
+}
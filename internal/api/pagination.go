@@ -0,0 +1,257 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+
+	"github.com/Megatherium/hf-go/internal/models"
+)
+
+// Pager lazily walks the pages of a Hub listing of models, datasets or
+// Spaces. Pages are only fetched when Next is called, so callers can stop at
+// any point without pulling the rest of the catalog.
+//
+//	pager := client.ModelPages(opts)
+//	for pager.Next() {
+//		for _, m := range pager.Page() {
+//			...
+//		}
+//	}
+//	if err := pager.Err(); err != nil {
+//		...
+//	}
+type Pager[T any] struct {
+	ctx     context.Context
+	fetch   func(ctx context.Context, reqURL, token string) ([]T, string, error)
+	token   string
+	limit   int
+	match   func(T) bool
+	nextURL string
+	count   int
+	page    []T
+	err     error
+}
+
+// ModelPager lazily walks the pages of a model listing
+type ModelPager = Pager[models.Model]
+
+// newPager returns a pager starting at firstURL. It stops after limit
+// records if limit is positive, and skips records not matching match if set.
+func newPager[T any](ctx context.Context, firstURL, token string, limit int, match func(T) bool,
+	fetch func(ctx context.Context, reqURL, token string) ([]T, string, error)) *Pager[T] {
+	return &Pager[T]{
+		ctx:     ctx,
+		fetch:   fetch,
+		token:   token,
+		limit:   limit,
+		match:   match,
+		nextURL: firstURL,
+	}
+}
+
+// ModelPages returns a pager over the models matching opts. If opts.Limit is
+// set, the pager stops once that many models have been returned.
+func (c *Client) ModelPages(opts models.ListModelsOptions) *ModelPager {
+	return c.ModelPagesContext(context.Background(), opts)
+}
+
+// ModelPagesContext is like ModelPages but carries ctx into every page request
+func (c *Client) ModelPagesContext(ctx context.Context, opts models.ListModelsOptions) *ModelPager {
+	return newPager(ctx, c.listModelsURL(opts), opts.Token, opts.Limit, opts.ClientMatch(), c.fetchModelsPage)
+}
+
+// Next fetches the next page. It returns false when there are no more pages,
+// the limit has been reached, or an error occurred. With a match predicate,
+// pages without any matching record are skipped.
+func (p *Pager[T]) Next() bool {
+	p.page = nil
+	for p.err == nil && p.nextURL != "" && (p.limit <= 0 || p.count < p.limit) {
+		page, next, err := p.fetch(p.ctx, p.nextURL, p.token)
+		if err != nil {
+			p.err = err
+			return false
+		}
+		p.nextURL = next
+
+		// An empty page means the listing is exhausted even if a cursor was sent
+		if len(page) == 0 {
+			p.nextURL = ""
+			return false
+		}
+
+		if p.match != nil {
+			page = filterPage(page, p.match)
+			if len(page) == 0 {
+				continue
+			}
+		}
+
+		if p.limit > 0 && p.count+len(page) > p.limit {
+			page = page[:p.limit-p.count]
+		}
+		p.count += len(page)
+		p.page = page
+		return true
+	}
+	return false
+}
+
+// All fetches the remaining pages and returns their records
+func (p *Pager[T]) All() ([]T, error) {
+	var result []T
+	for p.Next() {
+		result = append(result, p.page...)
+	}
+	if err := p.Err(); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// filterPage returns the records of page matching match, reusing its array
+func filterPage[T any](page []T, match func(T) bool) []T {
+	matched := page[:0]
+	for _, r := range page {
+		if match(r) {
+			matched = append(matched, r)
+		}
+	}
+	return matched
+}
+
+// Page returns the records of the page fetched by the last call to Next
+func (p *Pager[T]) Page() []T {
+	return p.page
+}
+
+// Err returns the first error encountered while fetching pages
+func (p *Pager[T]) Err() error {
+	return p.err
+}
+
+// GetPages issues a GET request for path on the client's endpoint and follows
+// the Link header, calling fn with the raw JSON body of every page. It stops
+// at the last page or at the first error returned by fn.
+func (c *Client) GetPages(ctx context.Context, path string, params url.Values, fn func(body []byte) error) error {
+	reqURL := c.URL(path)
+	if len(params) > 0 {
+		reqURL = fmt.Sprintf("%s?%s", reqURL, params.Encode())
+	}
+
+	for reqURL != "" {
+		body, next, err := c.fetchPage(ctx, reqURL, "")
+		if err != nil {
+			return err
+		}
+		if err := fn(body); err != nil {
+			return err
+		}
+		reqURL = next
+	}
+
+	return nil
+}
+
+// fetchJSONPage fetches a single page of a listing, decoding it as a JSON
+// array, and returns the URL of the next page
+func fetchJSONPage[T any](ctx context.Context, c *Client, reqURL, token string) ([]T, string, error) {
+	body, next, err := c.fetchPage(ctx, reqURL, token)
+	if err != nil {
+		return nil, "", err
+	}
+
+	var page []T
+	if err := json.Unmarshal(body, &page); err != nil {
+		return nil, "", fmt.Errorf("failed to parse response: %w", err)
+	}
+	return page, next, nil
+}
+
+// fetchPage fetches a single page and returns its body and the URL of the
+// next page, or an empty string if this was the last one
+func (c *Client) fetchPage(ctx context.Context, reqURL, token string) ([]byte, string, error) {
+	req, err := c.newRequest(ctx, "GET", reqURL, token)
+	if err != nil {
+		return nil, "", err
+	}
+
+	resp, err := c.do(req)
+	if err != nil {
+		return nil, "", err
+	}
+	defer resp.Body.Close()
+
+	// Parse response
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	next, err := nextPageURL(resp)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return body, next, nil
+}
+
+// nextPageURL extracts the rel="next" target from the response's Link header,
+// resolved against the request URL. It returns an empty string on the last page.
+func nextPageURL(resp *http.Response) (string, error) {
+	for _, header := range resp.Header.Values("Link") {
+		for _, link := range splitLinks(header) {
+			segments := strings.Split(link, ";")
+			target := strings.TrimSpace(segments[0])
+			if !strings.HasPrefix(target, "<") || !strings.HasSuffix(target, ">") {
+				continue
+			}
+
+			for _, param := range segments[1:] {
+				key, value, ok := strings.Cut(strings.TrimSpace(param), "=")
+				if !ok || strings.TrimSpace(key) != "rel" {
+					continue
+				}
+				// rel can hold several space-separated relations, e.g. "next last"
+				rels := strings.Fields(strings.Trim(strings.TrimSpace(value), `"`))
+				if !slices.Contains(rels, "next") {
+					continue
+				}
+
+				next, err := resp.Request.URL.Parse(strings.Trim(target, "<>"))
+				if err != nil {
+					return "", fmt.Errorf("invalid next page link %q: %w", target, err)
+				}
+				return next.String(), nil
+			}
+		}
+	}
+
+	return "", nil
+}
+
+// splitLinks splits a Link header into its links at the commas outside the
+// <...> targets, which may contain commas themselves
+func splitLinks(header string) []string {
+	var links []string
+	start, inTarget := 0, false
+	for i, r := range header {
+		switch r {
+		case '<':
+			inTarget = true
+		case '>':
+			inTarget = false
+		case ',':
+			if !inTarget {
+				links = append(links, header[start:i])
+				start = i + 1
+			}
+		}
+	}
+	return append(links, header[start:])
+}
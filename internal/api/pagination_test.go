@@ -0,0 +1,216 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/Megatherium/hf-go/internal/models"
+)
+
+// pagedServer serves a model listing of pages of size models, linking each
+// page to the next one with a relative rel="next" Link
+type pagedServer struct {
+	*httptest.Server
+	requests atomic.Int32
+}
+
+func newPagedServer(t *testing.T, pages, size int) *pagedServer {
+	t.Helper()
+	s := &pagedServer{}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.requests.Add(1)
+		page, _ := strconv.Atoi(r.URL.Query().Get("cursor"))
+		if page+1 < pages {
+			w.Header().Add("Link", fmt.Sprintf(`</api/models?cursor=%d>; rel="next", </api/models?cursor=%d>; rel="last"`, page+1, pages-1))
+		}
+		ids := make([]string, size)
+		for i := range ids {
+			ids[i] = fmt.Sprintf(`{"id":"org/m%d-%d"}`, page, i)
+		}
+		w.Write([]byte("[" + strings.Join(ids, ",") + "]"))
+	}))
+	t.Cleanup(s.Close)
+	return s
+}
+
+func (s *pagedServer) client() *Client {
+	c := NewClient("")
+	c.Endpoint = s.URL
+	return c
+}
+
+func modelIDs(list []models.Model) string {
+	ids := make([]string, len(list))
+	for i, m := range list {
+		ids[i] = strings.TrimPrefix(m.ID, "org/")
+	}
+	return strings.Join(ids, " ")
+}
+
+func TestPagerFollowsLinks(t *testing.T) {
+	srv := newPagedServer(t, 3, 2)
+	pager := srv.client().ModelPages(models.ListModelsOptions{})
+
+	var pages []string
+	for pager.Next() {
+		pages = append(pages, modelIDs(pager.Page()))
+	}
+	if err := pager.Err(); err != nil {
+		t.Fatalf("Err() = %v", err)
+	}
+	want := []string{"m0-0 m0-1", "m1-0 m1-1", "m2-0 m2-1"}
+	if strings.Join(pages, "|") != strings.Join(want, "|") {
+		t.Errorf("pages = %q, want %q", pages, want)
+	}
+	if n := srv.requests.Load(); n != 3 {
+		t.Errorf("got %d requests, want 3", n)
+	}
+	if pager.Next() || pager.Page() != nil {
+		t.Error("Next() after the last page should return false and clear the page")
+	}
+}
+
+func TestPagerLimit(t *testing.T) {
+	srv := newPagedServer(t, 5, 2)
+	list, err := srv.client().ListModels(models.ListModelsOptions{Limit: 3})
+	if err != nil {
+		t.Fatalf("ListModels error: %v", err)
+	}
+	// The limit cuts the second page in the middle and no third page is fetched
+	if got := modelIDs(list); got != "m0-0 m0-1 m1-0" {
+		t.Errorf("models = %q", got)
+	}
+	if n := srv.requests.Load(); n != 2 {
+		t.Errorf("got %d requests, want 2", n)
+	}
+}
+
+func TestPagerMatch(t *testing.T) {
+	srv := newPagedServer(t, 4, 3)
+	pager := srv.client().ModelPages(models.ListModelsOptions{
+		Limit: 2,
+		// Only the third page has matching models
+		Match: func(m models.Model) bool {
+			return strings.HasPrefix(m.ID, "org/m2-") && m.ID != "org/m2-1"
+		},
+	})
+
+	if !pager.Next() {
+		t.Fatalf("Next() = false, Err() = %v", pager.Err())
+	}
+	// Pages without a match are skipped rather than returned empty
+	if got := modelIDs(pager.Page()); got != "m2-0 m2-2" {
+		t.Errorf("page = %q, want the matches of the third page", got)
+	}
+	if pager.Next() {
+		t.Errorf("Next() after the limit returned %q", modelIDs(pager.Page()))
+	}
+	if n := srv.requests.Load(); n != 3 {
+		t.Errorf("got %d requests, want 3", n)
+	}
+}
+
+func TestPagerStopsOnEmptyPage(t *testing.T) {
+	var requests atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		w.Header().Set("Link", `</api/models?cursor=again>; rel="next"`)
+		w.Write([]byte("[]"))
+	}))
+	defer srv.Close()
+
+	c := NewClient("")
+	c.Endpoint = srv.URL
+	list, err := c.ListModels(models.ListModelsOptions{})
+	if err != nil || len(list) != 0 {
+		t.Fatalf("ListModels = %v, %v", list, err)
+	}
+	if n := requests.Load(); n != 1 {
+		t.Errorf("got %d requests, want 1", n)
+	}
+}
+
+func TestPagerError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("cursor") == "" {
+			w.Header().Set("Link", `</api/models?cursor=1>; rel="next"`)
+			w.Write([]byte(`[{"id":"org/a"}]`))
+			return
+		}
+		http.Error(w, "gone", http.StatusNotFound)
+	}))
+	defer srv.Close()
+
+	c := NewClient("")
+	c.Endpoint = srv.URL
+	pager := c.ModelPages(models.ListModelsOptions{})
+	if !pager.Next() {
+		t.Fatalf("Next() = false, Err() = %v", pager.Err())
+	}
+	if pager.Next() {
+		t.Fatal("Next() = true after a failed page")
+	}
+	if pager.Err() == nil {
+		t.Fatal("Err() = nil after a failed page")
+	}
+	if _, err := c.ListModels(models.ListModelsOptions{}); err == nil {
+		t.Error("ListModels error = nil, want the page error")
+	}
+}
+
+func TestNextPageURL(t *testing.T) {
+	tests := []struct {
+		name  string
+		links []string
+		want  string
+	}{
+		{"none", nil, ""},
+		{"next", []string{`<https://hub.test/api/models?cursor=abc>; rel="next"`}, "https://hub.test/api/models?cursor=abc"},
+		{"relative", []string{`</api/models?cursor=abc>; rel="next"`}, "https://hf.test/api/models?cursor=abc"},
+		{"unquoted rel", []string{`</api/models?cursor=abc>; rel=next`}, "https://hf.test/api/models?cursor=abc"},
+		{"several links", []string{`</api/models?cursor=p>; rel="prev", </api/models?cursor=n>; rel="next", </api/models?cursor=l>; rel="last"`}, "https://hf.test/api/models?cursor=n"},
+		{"several rels", []string{`</api/models?cursor=p>; rel="prev first", </api/models?cursor=n>; rel="next last"`}, "https://hf.test/api/models?cursor=n"},
+		{"comma in target", []string{`</api/models?cursor=a,b>; rel="next", </api/models?cursor=z>; rel="last"`}, "https://hf.test/api/models?cursor=a,b"},
+		{"other params", []string{`</api/models?cursor=n>; title="Next page"; rel="next"`}, "https://hf.test/api/models?cursor=n"},
+		{"several headers", []string{`</api/models?cursor=l>; rel="last"`, `</api/models?cursor=n>; rel="next"`}, "https://hf.test/api/models?cursor=n"},
+		{"last page", []string{`</api/models?cursor=f>; rel="first"`}, ""},
+		{"not a target", []string{`/api/models?cursor=n; rel="next"`}, ""},
+	}
+
+	reqURL, _ := url.Parse("https://hf.test/api/models?limit=10")
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := &http.Response{Header: http.Header{}, Request: &http.Request{URL: reqURL}}
+			for _, link := range tt.links {
+				resp.Header.Add("Link", link)
+			}
+			got, err := nextPageURL(resp)
+			if err != nil {
+				t.Fatalf("nextPageURL error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("nextPageURL = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPagerContext(t *testing.T) {
+	srv := newPagedServer(t, 3, 1)
+	ctx, cancel := context.WithCancel(context.Background())
+	pager := srv.client().ModelPagesContext(ctx, models.ListModelsOptions{})
+	if !pager.Next() {
+		t.Fatalf("Next() = false, Err() = %v", pager.Err())
+	}
+	cancel()
+	if pager.Next() || pager.Err() == nil {
+		t.Error("Next() should fail once the context is cancelled")
+	}
+}
@@ -0,0 +1,115 @@
+package api
+
+import (
+	"context"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// RetryPolicy controls how requests failing with a retryable status or a
+// network error are retried
+type RetryPolicy struct {
+	// MaxAttempts is the total number of attempts, including the first one.
+	// Values below 2 disable retries.
+	MaxAttempts int
+	// BaseDelay is the backoff before the first retry; it doubles on every attempt
+	BaseDelay time.Duration
+	// MaxDelay caps the backoff. A Retry-After longer than MaxDelay is not
+	// waited for and the request fails instead.
+	MaxDelay time.Duration
+	// RetryableStatus lists the HTTP status codes that are retried
+	RetryableStatus []int
+}
+
+// RetryFunc is called before a request is retried, with the attempt that
+// failed, the delay before the next one and the reason for the retry
+type RetryFunc func(req *http.Request, attempt int, delay time.Duration, err error)
+
+// DefaultRetryPolicy returns the retry policy used by NewClient
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts: 5,
+		BaseDelay:   time.Second,
+		MaxDelay:    time.Minute,
+		RetryableStatus: []int{
+			http.StatusTooManyRequests,
+			http.StatusInternalServerError,
+			http.StatusBadGateway,
+			http.StatusServiceUnavailable,
+			http.StatusGatewayTimeout,
+		},
+	}
+}
+
+// retryable reports whether a response with the given status should be retried
+func (p RetryPolicy) retryable(status int) bool {
+	for _, s := range p.RetryableStatus {
+		if s == status {
+			return true
+		}
+	}
+	return false
+}
+
+// backoff returns the jittered exponential delay before the given retry (1-based)
+func (p RetryPolicy) backoff(attempt int) time.Duration {
+	delay := p.BaseDelay
+	for i := 1; i < attempt && (p.MaxDelay <= 0 || delay < p.MaxDelay); i++ {
+		delay *= 2
+	}
+	if p.MaxDelay > 0 && delay > p.MaxDelay {
+		delay = p.MaxDelay
+	}
+	if delay <= 0 {
+		return 0
+	}
+
+	// Equal jitter: keep half of the delay and randomize the other half
+	half := delay / 2
+	return half + time.Duration(rand.Int63n(int64(delay-half)+1))
+}
+
+// serverDelay returns how long the server asked us to wait, from Retry-After
+// or the Hub's RateLimit header. It returns false if neither is present.
+func serverDelay(resp *http.Response) (time.Duration, bool) {
+	if value := strings.TrimSpace(resp.Header.Get("Retry-After")); value != "" {
+		if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+			return time.Duration(seconds) * time.Second, true
+		}
+		if at, err := http.ParseTime(value); err == nil {
+			return max(time.Until(at), 0), true
+		}
+	}
+
+	// The Hub reports its rate limit windows as: "api";r=0;t=42
+	// where t is the number of seconds until the window resets
+	for _, value := range resp.Header.Values("RateLimit") {
+		for _, param := range strings.Split(value, ";") {
+			key, v, ok := strings.Cut(strings.TrimSpace(param), "=")
+			if !ok || key != "t" {
+				continue
+			}
+			if seconds, err := strconv.Atoi(v); err == nil && seconds >= 0 {
+				return time.Duration(seconds) * time.Second, true
+			}
+		}
+	}
+
+	return 0, false
+}
+
+// sleep waits for d or until ctx is done
+func sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
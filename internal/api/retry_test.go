@@ -0,0 +1,229 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// retryServer answers every request with the next response of responses,
+// repeating the last one, and counts the requests
+type retryServer struct {
+	*httptest.Server
+	requests atomic.Int32
+}
+
+type response struct {
+	status int
+	header map[string]string
+	body   string
+}
+
+func newRetryServer(t *testing.T, responses ...response) *retryServer {
+	t.Helper()
+	s := &retryServer{}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := int(s.requests.Add(1))
+		resp := responses[min(n, len(responses))-1]
+		for k, v := range resp.header {
+			w.Header().Set(k, v)
+		}
+		w.WriteHeader(resp.status)
+		w.Write([]byte(resp.body))
+	}))
+	t.Cleanup(s.Close)
+	return s
+}
+
+// retryClient returns a client for srv whose retries are recorded in delays
+func retryClient(srv *retryServer, policy RetryPolicy, delays *[]time.Duration) *Client {
+	c := NewClient("")
+	c.Endpoint = srv.URL
+	c.Retry = policy
+	c.OnRetry = func(req *http.Request, attempt int, delay time.Duration, err error) {
+		*delays = append(*delays, delay)
+	}
+	return c
+}
+
+// testPolicy retries every retryable status with a backoff that would make
+// the test time out if it were waited for
+func testPolicy(attempts int) RetryPolicy {
+	p := DefaultRetryPolicy()
+	p.MaxAttempts = attempts
+	p.BaseDelay = time.Hour
+	p.MaxDelay = 2 * time.Hour
+	return p
+}
+
+func TestRetryAfter(t *testing.T) {
+	tests := []struct {
+		name   string
+		header map[string]string
+	}{
+		{"Retry-After seconds", map[string]string{"Retry-After": "0"}},
+		{"Retry-After date", map[string]string{"Retry-After": time.Now().Add(-time.Minute).UTC().Format(http.TimeFormat)}},
+		{"RateLimit", map[string]string{"RateLimit": `"api";r=0;t=0`}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := newRetryServer(t,
+				response{status: http.StatusTooManyRequests, header: tt.header},
+				response{status: http.StatusOK, body: `{"id":"org/model"}`},
+			)
+			var delays []time.Duration
+			c := retryClient(srv, testPolicy(3), &delays)
+
+			var v struct{ ID string }
+			if err := c.GetJSON(context.Background(), "/api/models/org/model", nil, &v); err != nil {
+				t.Fatalf("GetJSON error: %v", err)
+			}
+			if v.ID != "org/model" {
+				t.Errorf("decoded %+v", v)
+			}
+			if n := srv.requests.Load(); n != 2 {
+				t.Errorf("got %d requests, want 2", n)
+			}
+			// The server's delay replaces the hour-long backoff
+			if len(delays) != 1 || delays[0] != 0 {
+				t.Errorf("retry delays = %v, want [0s]", delays)
+			}
+		})
+	}
+}
+
+func TestRetryThenSuccess(t *testing.T) {
+	srv := newRetryServer(t,
+		response{status: http.StatusServiceUnavailable},
+		response{status: http.StatusBadGateway},
+		response{status: http.StatusOK, body: `{"id":"org/model"}`},
+	)
+	var delays []time.Duration
+	policy := testPolicy(5)
+	policy.BaseDelay = time.Millisecond
+	policy.MaxDelay = 4 * time.Millisecond
+	c := retryClient(srv, policy, &delays)
+
+	var v struct{ ID string }
+	if err := c.GetJSON(context.Background(), "/api/models/org/model", nil, &v); err != nil {
+		t.Fatalf("GetJSON error: %v", err)
+	}
+	if n := srv.requests.Load(); n != 3 {
+		t.Errorf("got %d requests, want 3", n)
+	}
+	// Equal jitter keeps at least half of the exponential delay
+	if len(delays) != 2 ||
+		delays[0] < time.Millisecond/2 || delays[0] > time.Millisecond ||
+		delays[1] < time.Millisecond || delays[1] > 2*time.Millisecond {
+		t.Errorf("retry delays = %v", delays)
+	}
+}
+
+func TestRetryExhausted(t *testing.T) {
+	srv := newRetryServer(t, response{status: http.StatusInternalServerError, body: `{"error":"boom"}`})
+	var delays []time.Duration
+	policy := testPolicy(3)
+	policy.BaseDelay = time.Millisecond
+	policy.MaxDelay = time.Millisecond
+	c := retryClient(srv, policy, &delays)
+
+	err := c.GetJSON(context.Background(), "/api/models", nil, &[]interface{}{})
+	var hubErr *HubError
+	if !errors.As(err, &hubErr) || hubErr.StatusCode != http.StatusInternalServerError || hubErr.ErrorMessage != "boom" {
+		t.Fatalf("GetJSON error = %v, want the 500 HubError", err)
+	}
+	if n := srv.requests.Load(); n != 3 {
+		t.Errorf("got %d requests, want 3", n)
+	}
+	if len(delays) != 2 {
+		t.Errorf("got %d retries, want 2", len(delays))
+	}
+}
+
+func TestRetryNotRetryable(t *testing.T) {
+	srv := newRetryServer(t, response{status: http.StatusNotFound, header: map[string]string{"X-Error-Code": "RepoNotFound"}})
+	var delays []time.Duration
+	c := retryClient(srv, testPolicy(5), &delays)
+
+	err := c.GetJSON(context.Background(), "/api/models/org/missing", nil, &struct{}{})
+	if !errors.Is(err, ErrRepoNotFound) {
+		t.Fatalf("GetJSON error = %v, want ErrRepoNotFound", err)
+	}
+	if n := srv.requests.Load(); n != 1 || len(delays) != 0 {
+		t.Errorf("got %d requests and %d retries, want 1 and 0", n, len(delays))
+	}
+}
+
+func TestRetryServerDelayAboveMax(t *testing.T) {
+	srv := newRetryServer(t,
+		response{status: http.StatusTooManyRequests, header: map[string]string{"Retry-After": "3600"}},
+		response{status: http.StatusOK, body: `{}`},
+	)
+	var delays []time.Duration
+	policy := testPolicy(5)
+	policy.MaxDelay = time.Minute
+	c := retryClient(srv, policy, &delays)
+
+	start := time.Now()
+	err := c.GetJSON(context.Background(), "/api/models", nil, &struct{}{})
+	if !errors.Is(err, ErrRateLimited) {
+		t.Fatalf("GetJSON error = %v, want ErrRateLimited", err)
+	}
+	if n := srv.requests.Load(); n != 1 || len(delays) != 0 {
+		t.Errorf("got %d requests and %d retries, want 1 and 0", n, len(delays))
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("failing took %v", elapsed)
+	}
+}
+
+func TestRetryCancelledDuringBackoff(t *testing.T) {
+	srv := newRetryServer(t, response{status: http.StatusServiceUnavailable})
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	c := NewClient("")
+	c.Endpoint = srv.URL
+	c.Retry = testPolicy(5)
+	c.OnRetry = func(req *http.Request, attempt int, delay time.Duration, err error) {
+		// Give up while the hour-long backoff is running
+		time.AfterFunc(10*time.Millisecond, cancel)
+	}
+
+	done := make(chan error, 1)
+	go func() {
+		done <- c.GetJSON(ctx, "/api/models", nil, &struct{}{})
+	}()
+
+	select {
+	case err := <-done:
+		var hubErr *HubError
+		if !errors.As(err, &hubErr) || hubErr.StatusCode != http.StatusServiceUnavailable {
+			t.Errorf("GetJSON error = %v, want the 503 HubError", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("GetJSON did not return after the context was cancelled")
+	}
+	if n := srv.requests.Load(); n != 1 {
+		t.Errorf("got %d requests, want 1", n)
+	}
+}
+
+func TestBackoff(t *testing.T) {
+	p := RetryPolicy{BaseDelay: time.Second, MaxDelay: 10 * time.Second}
+	for attempt, want := range map[int]time.Duration{1: time.Second, 2: 2 * time.Second, 3: 4 * time.Second, 4: 8 * time.Second, 5: 10 * time.Second, 50: 10 * time.Second} {
+		for range 20 {
+			if d := p.backoff(attempt); d < want/2 || d > want {
+				t.Fatalf("backoff(%d) = %v, want between %v and %v", attempt, d, want/2, want)
+			}
+		}
+	}
+	if d := (RetryPolicy{}).backoff(1); d != 0 {
+		t.Errorf("backoff without a base delay = %v, want 0", d)
+	}
+}
@@ -0,0 +1,124 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/Megatherium/hf-go/internal/models"
+)
+
+// apiSpace represents the raw Space response from the API
+type apiSpace struct {
+	ID            string               `json:"id"`
+	Author        string               `json:"author"`
+	Likes         int                  `json:"likes"`
+	LastModified  time.Time            `json:"lastModified"`
+	CreatedAt     time.Time            `json:"createdAt"`
+	Private       bool                 `json:"private"`
+	SDK           string               `json:"sdk"`
+	TrendingScore float64              `json:"trendingScore"`
+	Tags          []string             `json:"tags"`
+	Models        []string             `json:"models"`
+	Datasets      []string             `json:"datasets"`
+	Runtime       *models.SpaceRuntime `json:"runtime"`
+}
+
+// SpacePager lazily walks the pages of a Space listing
+type SpacePager = Pager[models.Space]
+
+// ListSpaces fetches Spaces from the Hugging Face Hub, following the
+// pagination until opts.Limit Spaces have been collected
+func (c *Client) ListSpaces(opts models.ListSpacesOptions) ([]models.Space, error) {
+	return c.ListSpacesContext(context.Background(), opts)
+}
+
+// ListSpacesContext is like ListSpaces but carries ctx into every page request
+func (c *Client) ListSpacesContext(ctx context.Context, opts models.ListSpacesOptions) ([]models.Space, error) {
+	return c.SpacePagesContext(ctx, opts).All()
+}
+
+// SpacePages returns a pager over the Spaces matching opts
+func (c *Client) SpacePages(opts models.ListSpacesOptions) *SpacePager {
+	return c.SpacePagesContext(context.Background(), opts)
+}
+
+// SpacePagesContext is like SpacePages but carries ctx into every page request
+func (c *Client) SpacePagesContext(ctx context.Context, opts models.ListSpacesOptions) *SpacePager {
+	return newPager(ctx, c.listSpacesURL(opts), opts.Token, opts.Limit, opts.Match, c.fetchSpacesPage)
+}
+
+// listSpacesURL builds the URL of the first page of a Space listing
+func (c *Client) listSpacesURL(opts models.ListSpacesOptions) string {
+	params := url.Values{}
+
+	if opts.Search != "" {
+		params.Add("search", opts.Search)
+	}
+	if opts.Author != "" {
+		params.Add("author", opts.Author)
+	}
+	for _, filter := range append(append([]string{}, opts.Filters...), opts.Tags...) {
+		params.Add("filter", filter)
+	}
+	for _, model := range opts.Models {
+		params.Add("models", model)
+	}
+	for _, dataset := range opts.Datasets {
+		params.Add("datasets", dataset)
+	}
+	if limit := opts.Limit; limit > 0 {
+		if opts.Match != nil && limit < matchPageSize {
+			limit = matchPageSize
+		}
+		params.Add("limit", strconv.Itoa(limit))
+	}
+	if opts.Sort != "" {
+		params.Add("sort", opts.Sort)
+	}
+	if opts.Direction != 0 {
+		params.Add("direction", strconv.Itoa(opts.Direction))
+	}
+	if expand := opts.ExpandFields(); len(expand) > 0 {
+		for _, field := range expand {
+			params.Add("expand[]", field)
+		}
+	} else if opts.Linked || len(opts.Models) > 0 || len(opts.Datasets) > 0 {
+		params.Add("linked", "true")
+	}
+
+	if len(params) == 0 {
+		return c.URL("/api/spaces")
+	}
+	return fmt.Sprintf("%s?%s", c.URL("/api/spaces"), params.Encode())
+}
+
+// fetchSpacesPage fetches a single page of Spaces and returns the URL of the
+// next page, or an empty string if this was the last one
+func (c *Client) fetchSpacesPage(ctx context.Context, reqURL, token string) ([]models.Space, string, error) {
+	apiSpaces, next, err := fetchJSONPage[apiSpace](ctx, c, reqURL, token)
+	if err != nil {
+		return nil, "", err
+	}
+
+	result := make([]models.Space, len(apiSpaces))
+	for i, as := range apiSpaces {
+		result[i] = models.Space{
+			ID:            as.ID,
+			Author:        RepoAuthor(as.Author, as.ID),
+			Likes:         as.Likes,
+			LastModified:  as.LastModified,
+			CreatedAt:     as.CreatedAt,
+			Private:       as.Private,
+			SDK:           as.SDK,
+			TrendingScore: as.TrendingScore,
+			Tags:          as.Tags,
+			Models:        as.Models,
+			Datasets:      as.Datasets,
+			Runtime:       as.Runtime,
+		}
+	}
+	return result, next, nil
+}
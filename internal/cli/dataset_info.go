@@ -0,0 +1,105 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	hfmodels "github.com/Megatherium/hf-go"
+	"github.com/Megatherium/hf-go/internal/pkg/utils"
+	"github.com/spf13/cobra"
+)
+
+// DatasetInfoOptions holds the CLI flags for the dataset-info command
+type DatasetInfoOptions struct {
+	Revision     string
+	OutputFormat string
+	Token        string
+}
+
+// NewDatasetInfoCmd creates the dataset-info command
+func NewDatasetInfoCmd() *cobra.Command {
+	opts := &DatasetInfoOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "dataset-info <dataset>",
+		Short: "Show the details of a dataset",
+		Long: `Show the details of a dataset: author, license, task and size categories,
+languages, tags, access control and the files of the repository with their
+sizes.
+
+Examples:
+  # Details of a dataset
+  hf-go dataset-info rajpurkar/squad
+
+  # Details as of a tag, as JSON
+  hf-go dataset-info --revision v1.0 --output-format json org/dataset
+`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runDatasetInfo(cmd, args[0], opts)
+		},
+	}
+
+	// Add flags
+	cmd.Flags().StringVar(&opts.Revision, "revision", "", "Branch, tag or commit hash (default 'main')")
+	cmd.Flags().StringVar(&opts.OutputFormat, "output-format", "table", "Output format: 'table' or 'json'")
+	cmd.Flags().StringVar(&opts.Token, "token", "", "Hugging Face API token (optional, can also use HF_TOKEN env var)")
+
+	return cmd
+}
+
+// runDatasetInfo executes the dataset-info command
+func runDatasetInfo(cmd *cobra.Command, datasetID string, opts *DatasetInfoOptions) error {
+	if opts.OutputFormat != "table" && opts.OutputFormat != "json" {
+		return fmt.Errorf("unsupported output format: %s (use 'table' or 'json')", opts.OutputFormat)
+	}
+
+	client := newClient(cmd, resolveToken(opts.Token))
+
+	details, err := client.DatasetInfoWithOptions(cmd.Context(), datasetID, hfmodels.DatasetInfoOptions{
+		Revision: opts.Revision,
+		Blobs:    true,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to get dataset details: %w", err)
+	}
+
+	if opts.OutputFormat == "json" {
+		return printJSON(cmd, details)
+	}
+	printDatasetDetails(cmd.OutOrStdout(), details)
+	return nil
+}
+
+// printDatasetDetails prints the details of a dataset followed by its files
+func printDatasetDetails(out io.Writer, d *hfmodels.DatasetDetails) {
+	rows := [][]string{
+		{"ID", d.ID},
+		{"Author", d.Author},
+		{"Commit", d.SHA},
+	}
+	if d.CardData.PrettyName != "" {
+		rows = append(rows, []string{"Name", d.CardData.PrettyName})
+	}
+	rows = append(rows,
+		[]string{"License", utils.OrNA(d.CardData.GetLicense())},
+		[]string{"Tasks", utils.OrNA(strings.Join(d.CardData.GetTaskCategories(), ", "))},
+		[]string{"Size", utils.OrNA(strings.Join(d.CardData.GetSizeCategories(), ", "))},
+		[]string{"Languages", utils.OrNA(strings.Join(d.CardData.GetLanguages(), ", "))},
+		[]string{"Downloads", utils.CommaNumber(d.Downloads)},
+		[]string{"Likes", utils.CommaNumber(d.Likes)},
+		[]string{"Created", utils.OrNA(utils.FormatDate(d.CreatedAt))},
+		[]string{"Last Modified", utils.OrNA(utils.FormatDate(d.LastModified))},
+		[]string{"Gated", formatGated(d.Gated)},
+		[]string{"Private", strconv.FormatBool(d.Private)},
+	)
+	if d.Disabled {
+		rows = append(rows, []string{"Disabled", "true"})
+	}
+	rows = append(rows, []string{"Tags", utils.OrNA(strings.Join(d.Tags, ", "))})
+	fmt.Fprintln(out, utils.RenderTable([]string{"Field", "Value"}, rows))
+
+	printFiles(out, d.Siblings)
+}
@@ -0,0 +1,93 @@
+package cli
+
+import (
+	"fmt"
+
+	hfmodels "github.com/Megatherium/hf-go"
+	"github.com/spf13/cobra"
+)
+
+// DownloadOptions holds the CLI flags for the download command
+type DownloadOptions struct {
+	Include    []string
+	Exclude    []string
+	Quant      string
+	Revision   string
+	LocalDir   string
+	CacheDir   string
+	MaxWorkers int
+	Token      string
+}
+
+// NewDownloadCmd creates the download command
+func NewDownloadCmd() *cobra.Command {
+	opts := &DownloadOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "download <model> [files...]",
+		Short: "Download files of a model",
+		Long: `Download a whole model repository, or the given files, the files matching
+--include and not --exclude, and the shards of a GGUF quant.
+
+Files go into the huggingface_hub cache shared with the Python library, or
+into --local-dir as plain files. The directory is printed once done.
+Interrupted downloads resume where they stopped.
+
+Exit codes: 2 if the model, revision, file or quant does not exist, 3 if the
+token is missing or invalid or the model is gated, 4 on network failures.
+
+Examples:
+  # Download a whole model into the cache
+  hf-go download google-bert/bert-base-uncased
+
+  # All shards of one quant, plus the projector of a multimodal model
+  hf-go download --quant Q4_K_M unsloth/gemma-3-12b-it-GGUF
+
+  # The config and safetensors weights into a directory
+  hf-go download --include '*.safetensors' --include '*.json' --local-dir ./qwen Qwen/Qwen3-0.6B
+
+  # Specific files
+  hf-go download Qwen/Qwen3-0.6B config.json tokenizer.json
+`,
+		Args: cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runDownload(cmd, args[0], args[1:], opts)
+		},
+	}
+
+	// Add flags
+	cmd.Flags().StringArrayVar(&opts.Include, "include", nil, "Download files matching this glob (repeatable)")
+	cmd.Flags().StringArrayVar(&opts.Exclude, "exclude", nil, "Skip files matching this glob (repeatable)")
+	cmd.Flags().StringVar(&opts.Quant, "quant", "", "Download all shards of this GGUF quant, e.g. 'Q4_K_M'")
+	cmd.Flags().StringVar(&opts.Revision, "revision", "", "Branch, tag or commit hash (default 'main')")
+	cmd.Flags().StringVar(&opts.LocalDir, "local-dir", "", "Download plain files into this directory instead of the cache")
+	cmd.Flags().StringVar(&opts.CacheDir, "cache-dir", "", "Cache directory (default: $HF_HUB_CACHE or ~/.cache/huggingface/hub)")
+	cmd.Flags().IntVar(&opts.MaxWorkers, "max-workers", hfmodels.DefaultMaxWorkers, "Maximum number of files downloaded in parallel")
+	cmd.Flags().StringVar(&opts.Token, "token", "", "Hugging Face API token (optional, can also use HF_TOKEN env var)")
+
+	return cmd
+}
+
+// runDownload executes the download command
+func runDownload(cmd *cobra.Command, modelID string, files []string, opts *DownloadOptions) error {
+	client := newClient(cmd, resolveToken(opts.Token), hfmodels.WithCacheDir(opts.CacheDir))
+
+	progress := newProgressDisplay(cmd.ErrOrStderr())
+	dir, err := client.SnapshotDownload(cmd.Context(), modelID, opts.Revision, hfmodels.SnapshotOptions{
+		Files:          files,
+		AllowPatterns:  opts.Include,
+		IgnorePatterns: opts.Exclude,
+		Quant:          opts.Quant,
+		LocalDir:       opts.LocalDir,
+		MaxWorkers:     opts.MaxWorkers,
+		OnStart:        progress.start,
+		Progress:       progress.update,
+	})
+	progress.finish()
+	if err != nil {
+		return fmt.Errorf("failed to download %s: %w", modelID, err)
+	}
+
+	fmt.Fprintln(cmd.OutOrStdout(), dir)
+	return nil
+}
@@ -0,0 +1,61 @@
+package cli
+
+import (
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+
+	hfmodels "github.com/Megatherium/hf-go"
+)
+
+// Exit codes of the CLI, so that scripts can tell failures apart
+const (
+	ExitOK = 0
+	// ExitError is any other failure, including invalid flags
+	ExitError = 1
+	// ExitNotFound is a missing repository, revision, file or quant
+	ExitNotFound = 2
+	// ExitAuth is a missing or invalid token, or a gated repository
+	ExitAuth = 3
+	// ExitNetwork is a connection failure, timeout, rate limit or server error
+	ExitNetwork = 4
+)
+
+// ExitCode returns the exit code the CLI terminates with after err
+func ExitCode(err error) int {
+	if err == nil {
+		return ExitOK
+	}
+
+	switch {
+	case errors.Is(err, hfmodels.ErrRepoNotFound),
+		errors.Is(err, hfmodels.ErrRevisionNotFound),
+		errors.Is(err, hfmodels.ErrEntryNotFound),
+		errors.Is(err, hfmodels.ErrQuantNotFound),
+		errors.Is(err, hfmodels.ErrNoQuantVariants),
+		errors.Is(err, hfmodels.ErrNoSafetensors):
+		return ExitNotFound
+	case errors.Is(err, hfmodels.ErrUnauthorized), errors.Is(err, hfmodels.ErrGatedRepo):
+		return ExitAuth
+	case errors.Is(err, hfmodels.ErrRateLimited):
+		return ExitNetwork
+	}
+
+	var hubErr *hfmodels.HubError
+	if errors.As(err, &hubErr) {
+		if hubErr.StatusCode >= http.StatusInternalServerError {
+			return ExitNetwork
+		}
+		return ExitError
+	}
+
+	var urlErr *url.Error
+	var netErr net.Error
+	if errors.As(err, &urlErr) || errors.As(err, &netErr) || errors.Is(err, io.ErrUnexpectedEOF) {
+		return ExitNetwork
+	}
+
+	return ExitError
+}
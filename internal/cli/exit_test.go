@@ -0,0 +1,44 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"testing"
+
+	hfmodels "github.com/Megatherium/hf-go"
+)
+
+func TestExitCode(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want int
+	}{
+		{"nil", nil, ExitOK},
+		{"other", errors.New("invalid flag"), ExitError},
+		{"repo not found", fmt.Errorf("failed: %w", hfmodels.ErrRepoNotFound), ExitNotFound},
+		{"revision not found", hfmodels.ErrRevisionNotFound, ExitNotFound},
+		{"entry not found", hfmodels.ErrEntryNotFound, ExitNotFound},
+		{"quant not found", fmt.Errorf("Q4_K_M: %w", hfmodels.ErrQuantNotFound), ExitNotFound},
+		{"no quants", hfmodels.ErrNoQuantVariants, ExitNotFound},
+		{"no safetensors", hfmodels.ErrNoSafetensors, ExitNotFound},
+		{"unauthorized", hfmodels.ErrUnauthorized, ExitAuth},
+		{"gated", fmt.Errorf("failed: %w", hfmodels.ErrGatedRepo), ExitAuth},
+		{"rate limited", hfmodels.ErrRateLimited, ExitNetwork},
+		{"server error", fmt.Errorf("failed: %w", &hfmodels.HubError{StatusCode: 502}), ExitNetwork},
+		{"client error", &hfmodels.HubError{StatusCode: 400}, ExitError},
+		{"connection", &url.Error{Op: "Get", URL: "https://huggingface.co", Err: errors.New("connection refused")}, ExitNetwork},
+		{"truncated", fmt.Errorf("read: %w", io.ErrUnexpectedEOF), ExitNetwork},
+		{"cancelled", context.Canceled, ExitError},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ExitCode(tt.err); got != tt.want {
+				t.Errorf("ExitCode(%v) = %d, want %d", tt.err, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,311 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	hfmodels "github.com/Megatherium/hf-go"
+	"github.com/Megatherium/hf-go/internal/pkg/utils"
+	"github.com/spf13/cobra"
+)
+
+// maxValueWidth truncates long metadata values in table output
+const maxValueWidth = 60
+
+// InspectOptions holds the CLI flags for the inspect command
+type InspectOptions struct {
+	Revision     string
+	Tensors      bool
+	OutputFormat string
+	Token        string
+}
+
+// NewInspectCmd creates the inspect command
+func NewInspectCmd() *cobra.Command {
+	opts := &InspectOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "inspect <model> [file]",
+		Short: "Inspect the weights of a model without downloading them",
+		Long: `Read the headers of a model's safetensors or GGUF files with HTTP Range
+requests and report parameter counts, dtypes, tensor shapes and metadata,
+without downloading the weights.
+
+Without a file, all safetensors weights of the model are inspected, resolving
+sharded checkpoints through model.safetensors.index.json. A local .gguf or
+.safetensors path can be given instead of a model.
+
+Examples:
+  # Parameters per dtype of a safetensors model
+  hf-go inspect meta-llama/Llama-3.1-8B-Instruct
+
+  # Metadata of a single GGUF file
+  hf-go inspect unsloth/Qwen3-8B-GGUF Qwen3-8B-Q4_K_M.gguf
+
+  # Every tensor with its shape
+  hf-go inspect --tensors Qwen/Qwen3-0.6B
+`,
+		Args: cobra.RangeArgs(1, 2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runInspect(cmd, args, opts)
+		},
+	}
+
+	// Add flags
+	cmd.Flags().StringVar(&opts.Revision, "revision", "", "Branch, tag or commit hash (default 'main')")
+	cmd.Flags().BoolVar(&opts.Tensors, "tensors", false, "List every tensor with its dtype and shape")
+	cmd.Flags().StringVar(&opts.OutputFormat, "output-format", "table", "Output format: 'table' or 'json'")
+	cmd.Flags().StringVar(&opts.Token, "token", "", "Hugging Face API token (optional, can also use HF_TOKEN env var)")
+
+	return cmd
+}
+
+// runInspect executes the inspect command
+func runInspect(cmd *cobra.Command, args []string, opts *InspectOptions) error {
+	if opts.OutputFormat != "table" && opts.OutputFormat != "json" {
+		return fmt.Errorf("unsupported output format: %s (use 'table' or 'json')", opts.OutputFormat)
+	}
+
+	// A local file
+	if len(args) == 1 && isWeightsFile(args[0]) {
+		if info, err := os.Stat(args[0]); err == nil && !info.IsDir() {
+			return inspectLocal(cmd, args[0], opts)
+		}
+	}
+
+	client := newClient(cmd, resolveToken(opts.Token))
+	modelID := args[0]
+
+	if len(args) == 1 {
+		meta, err := client.InspectSafetensorsRepo(cmd.Context(), modelID, opts.Revision)
+		if err != nil {
+			return fmt.Errorf("failed to inspect %s: %w", modelID, err)
+		}
+		if opts.OutputFormat == "json" {
+			return printJSON(cmd, meta)
+		}
+		printSafetensorsRepo(cmd.OutOrStdout(), meta, opts.Tensors)
+		return nil
+	}
+
+	filename := args[1]
+	switch {
+	case strings.HasSuffix(filename, ".gguf"):
+		header, err := client.InspectGGUF(cmd.Context(), modelID, filename, opts.Revision)
+		if err != nil {
+			return fmt.Errorf("failed to inspect %s: %w", filename, err)
+		}
+		return printGGUFHeader(cmd, filename, header, opts)
+	case strings.HasSuffix(filename, ".safetensors"):
+		header, err := client.InspectSafetensors(cmd.Context(), modelID, filename, opts.Revision)
+		if err != nil {
+			return fmt.Errorf("failed to inspect %s: %w", filename, err)
+		}
+		return printSafetensorsHeader(cmd, filename, header, opts)
+	}
+	return fmt.Errorf("unsupported file %s (use a .gguf or .safetensors file)", filename)
+}
+
+// isWeightsFile reports whether path names a file the inspect command can read
+func isWeightsFile(path string) bool {
+	return strings.HasSuffix(path, ".gguf") || strings.HasSuffix(path, ".safetensors")
+}
+
+// inspectLocal inspects a local GGUF or safetensors file
+func inspectLocal(cmd *cobra.Command, path string, opts *InspectOptions) error {
+	if strings.HasSuffix(path, ".gguf") {
+		header, err := hfmodels.InspectGGUFFile(path)
+		if err != nil {
+			return err
+		}
+		return printGGUFHeader(cmd, path, header, opts)
+	}
+
+	header, err := hfmodels.InspectSafetensorsFile(path)
+	if err != nil {
+		return err
+	}
+	return printSafetensorsHeader(cmd, path, header, opts)
+}
+
+// printSafetensorsRepo prints the safetensors weights of a repository
+func printSafetensorsRepo(out io.Writer, meta *hfmodels.SafetensorsRepoMetadata, tensors bool) {
+	fmt.Fprintf(out, "Model: %s\n", meta.RepoID)
+	if meta.Commit != "" {
+		fmt.Fprintf(out, "Commit: %s\n", meta.Commit)
+	}
+	if len(meta.Indexes) > 0 {
+		fmt.Fprintf(out, "Index: %s\n", strings.Join(meta.Indexes, ", "))
+	}
+	fmt.Fprintf(out, "Files: %d\n", len(meta.Files))
+	fmt.Fprintf(out, "Parameters: %s\n", formatParameters(meta.TotalParameters))
+	fmt.Fprintf(out, "Tensor data: %s\n\n", utils.FormatBytes(int64(meta.TotalBytes)))
+
+	fmt.Fprintln(out, renderDTypes(meta.Parameters, meta.TotalParameters))
+
+	// Metadata is usually identical across shards, e.g. {"format": "pt"}
+	metadata := make(map[string]string)
+	for _, f := range meta.Files {
+		for k, v := range f.Metadata {
+			metadata[k] = v
+		}
+	}
+	if len(metadata) > 0 {
+		fmt.Fprintln(out)
+		fmt.Fprintln(out, renderStringMap(metadata))
+	}
+
+	if tensors {
+		headers := []string{"Tensor", "DType", "Shape", "Size", "File"}
+		var rows [][]string
+		for _, f := range meta.Files {
+			for _, t := range f.Tensors {
+				rows = append(rows, []string{t.Name, t.DType, formatShape(t.Shape), utils.FormatBytes(int64(t.Bytes())), f.Filename})
+			}
+		}
+		fmt.Fprintln(out)
+		fmt.Fprintln(out, utils.RenderTable(headers, rows))
+	}
+}
+
+// printSafetensorsHeader prints the header of a single safetensors file
+func printSafetensorsHeader(cmd *cobra.Command, filename string, header *hfmodels.SafetensorsHeader, opts *InspectOptions) error {
+	if opts.OutputFormat == "json" {
+		return printJSON(cmd, header)
+	}
+
+	out := cmd.OutOrStdout()
+	total := header.ParameterCount()
+	fmt.Fprintf(out, "File: %s (safetensors)\n", filename)
+	fmt.Fprintf(out, "Tensors: %d\n", len(header.Tensors))
+	fmt.Fprintf(out, "Parameters: %s\n\n", formatParameters(total))
+
+	fmt.Fprintln(out, renderDTypes(header.Parameters(), total))
+	if len(header.Metadata) > 0 {
+		fmt.Fprintln(out)
+		fmt.Fprintln(out, renderStringMap(header.Metadata))
+	}
+
+	if opts.Tensors {
+		headers := []string{"Tensor", "DType", "Shape", "Size"}
+		rows := make([][]string, len(header.Tensors))
+		for i, t := range header.Tensors {
+			rows[i] = []string{t.Name, t.DType, formatShape(t.Shape), utils.FormatBytes(int64(t.Bytes()))}
+		}
+		fmt.Fprintln(out)
+		fmt.Fprintln(out, utils.RenderTable(headers, rows))
+	}
+	return nil
+}
+
+// printGGUFHeader prints the header of a GGUF file
+func printGGUFHeader(cmd *cobra.Command, filename string, header *hfmodels.GGUFHeader, opts *InspectOptions) error {
+	if opts.OutputFormat == "json" {
+		return printJSON(cmd, header)
+	}
+
+	out := cmd.OutOrStdout()
+	total := header.ParameterCount()
+	fmt.Fprintf(out, "File: %s (GGUF v%d)\n", filename, header.Version)
+	if arch := header.Architecture(); arch != "" {
+		fmt.Fprintf(out, "Architecture: %s\n", arch)
+	}
+	if ft, ok := header.FileType(); ok {
+		fmt.Fprintf(out, "File type: %s\n", ft)
+	}
+	fmt.Fprintf(out, "Tensors: %d\n", len(header.Tensors))
+	fmt.Fprintf(out, "Parameters: %s\n", formatParameters(total))
+	fmt.Fprintf(out, "Header: %s\n\n", utils.FormatBytes(header.HeaderBytes))
+
+	headers := []string{"Key", "Type", "Value"}
+	rows := make([][]string, len(header.Metadata))
+	for i, kv := range header.Metadata {
+		rows[i] = []string{kv.Key, kv.Type.String(), formatMetadataValue(kv.Value)}
+	}
+	fmt.Fprintln(out, utils.RenderTable(headers, rows))
+
+	if opts.Tensors {
+		headers := []string{"Tensor", "Type", "Shape"}
+		rows := make([][]string, len(header.Tensors))
+		for i, t := range header.Tensors {
+			rows[i] = []string{t.Name, t.Type.String(), formatShape(t.Dimensions)}
+		}
+		fmt.Fprintln(out)
+		fmt.Fprintln(out, utils.RenderTable(headers, rows))
+	}
+	return nil
+}
+
+// renderDTypes renders the parameters per dtype, largest first
+func renderDTypes(params map[string]uint64, total uint64) string {
+	dtypes := make([]string, 0, len(params))
+	for dtype := range params {
+		dtypes = append(dtypes, dtype)
+	}
+	sort.Slice(dtypes, func(i, j int) bool {
+		if params[dtypes[i]] != params[dtypes[j]] {
+			return params[dtypes[i]] > params[dtypes[j]]
+		}
+		return dtypes[i] < dtypes[j]
+	})
+
+	headers := []string{"DType", "Parameters", "Share"}
+	rows := make([][]string, len(dtypes))
+	for i, dtype := range dtypes {
+		share := 0.0
+		if total > 0 {
+			share = 100 * float64(params[dtype]) / float64(total)
+		}
+		rows[i] = []string{dtype, utils.CommaNumber(int(params[dtype])), fmt.Sprintf("%.1f%%", share)}
+	}
+	return utils.RenderTable(headers, rows)
+}
+
+// renderStringMap renders string metadata sorted by key
+func renderStringMap(m map[string]string) string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	rows := make([][]string, len(keys))
+	for i, k := range keys {
+		rows[i] = []string{k, utils.Truncate(m[k], maxValueWidth)}
+	}
+	return utils.RenderTable([]string{"Metadata", "Value"}, rows)
+}
+
+// formatParameters formats a parameter count in full and abbreviated, e.g.
+// "8,030,261,248 (8.03B)"
+func formatParameters(n uint64) string {
+	if n < 1000 {
+		return utils.CommaNumber(int(n))
+	}
+	return utils.CommaNumber(int(n)) + " (" + utils.FormatCount(n) + ")"
+}
+
+// formatShape formats tensor dimensions, e.g. "[4096 128256]"
+func formatShape(dims []uint64) string {
+	parts := make([]string, len(dims))
+	for i, d := range dims {
+		parts[i] = strconv.FormatUint(d, 10)
+	}
+	return "[" + strings.Join(parts, " ") + "]"
+}
+
+// formatMetadataValue formats a GGUF metadata value, summarizing arrays such
+// as the tokenizer vocabulary
+func formatMetadataValue(v interface{}) string {
+	switch v := v.(type) {
+	case []interface{}:
+		return fmt.Sprintf("[%d items]", len(v))
+	case string:
+		return utils.Truncate(strings.ReplaceAll(v, "\n", `\n`), maxValueWidth)
+	}
+	return utils.Truncate(fmt.Sprint(v), maxValueWidth)
+}
@@ -0,0 +1,123 @@
+package cli
+
+import (
+	"github.com/Megatherium/hf-go/internal/models"
+	"github.com/Megatherium/hf-go/internal/pkg/utils"
+	"github.com/spf13/cobra"
+)
+
+// ListDatasetsOptions holds the CLI flags for the list-datasets command
+type ListDatasetsOptions struct {
+	Search       string
+	Author       string
+	Filter       []string
+	Tag          []string
+	TaskCategory []string
+	SizeCategory []string
+	Language     []string
+	Limit        int
+	Sort         string
+	Direction    int
+	Full         bool
+	Columns      []string
+	Template     string
+	Human        bool
+	OutputFormat string
+	Token        string
+}
+
+// NewListDatasetsCmd creates the list-datasets command
+func NewListDatasetsCmd() *cobra.Command {
+	opts := &ListDatasetsOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "list-datasets",
+		Short: "List datasets from the Hugging Face Hub",
+		Long: `List datasets from the Hugging Face Hub with various filters and output formats.
+
+--filter, --tag, --task-category, --size-category and --language can be
+repeated; datasets must have all the values.
+
+--columns picks the columns of the table, csv, tsv and markdown output: id,
+author, downloads, likes, last_modified, task_categories, size_categories,
+languages, license, trending_score, private, gated, created_at, sha and
+tags. --template prints every dataset with a Go template over the Dataset
+fields.
+
+Examples:
+  # The most downloaded datasets
+  hf-go list-datasets --sort downloads --direction -1
+
+  # English text classification datasets of 10K to 100K rows
+  hf-go list-datasets --task-category text-classification --language en --size-category '10K<n<100K'
+
+  # Datasets of an organization as CSV
+  hf-go list-datasets --author HuggingFaceFW --output-format csv
+
+  # One line per dataset with a Go template
+  hf-go list-datasets --search squad --template '{{.ID}} {{.Downloads}}'
+`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runListDatasets(cmd, opts)
+		},
+	}
+
+	// Add flags
+	cmd.Flags().StringVar(&opts.Search, "search", "", "Search for datasets with this string in their id")
+	cmd.Flags().StringVar(&opts.Author, "author", "", "Filter datasets by author (username or organization)")
+	cmd.Flags().StringArrayVar(&opts.Filter, "filter", nil, "Filter datasets by tag as the Hub writes it, e.g. 'modality:text' (repeatable)")
+	cmd.Flags().StringArrayVar(&opts.Tag, "tag", nil, "Filter datasets by specific tag (repeatable)")
+	cmd.Flags().StringArrayVar(&opts.TaskCategory, "task-category", nil, "Filter datasets by task category (e.g., 'text-classification') (repeatable)")
+	cmd.Flags().StringArrayVar(&opts.SizeCategory, "size-category", nil, "Filter datasets by size category (e.g., '1K<n<10K') (repeatable)")
+	cmd.Flags().StringArrayVar(&opts.Language, "language", nil, "Filter datasets by language (e.g., 'en', 'fr') (repeatable)")
+	cmd.Flags().IntVar(&opts.Limit, "limit", 20, "Maximum number of datasets to return")
+	cmd.Flags().StringVar(&opts.Sort, "sort", "", "Sort results by field (e.g., 'downloads', 'likes', 'trending_score')")
+	cmd.Flags().IntVar(&opts.Direction, "direction", 0, "Sort direction: -1 for descending, 1 for ascending")
+	cmd.Flags().BoolVar(&opts.Full, "full", false, "Fetch every dataset property (description, commit...)")
+	cmd.Flags().StringSliceVar(&opts.Columns, "columns", nil, "Columns of the table, csv, tsv and markdown output, e.g. 'id,downloads,license,languages'")
+	cmd.Flags().BoolVar(&opts.Human, "human", false, "Show counts in compact form (1.23M) instead of with thousands separators")
+	cmd.Flags().StringVar(&opts.Template, "template", "", "Print every dataset with a Go template instead, e.g. '{{.ID}} {{.Downloads}}'")
+	cmd.Flags().StringVar(&opts.OutputFormat, "output-format", "table", "Output format: table, json, ndjson, yaml, csv, tsv or markdown")
+	cmd.Flags().StringVar(&opts.Token, "token", "", "Hugging Face API token (optional, can also use HF_TOKEN env var)")
+
+	return cmd
+}
+
+// runListDatasets executes the list-datasets command
+func runListDatasets(cmd *cobra.Command, opts *ListDatasetsOptions) error {
+	token := resolveToken(opts.Token)
+	client := newAPIClient(cmd, token)
+
+	apiOpts := models.ListDatasetsOptions{
+		Search:         opts.Search,
+		Author:         opts.Author,
+		Filters:        opts.Filter,
+		Tags:           opts.Tag,
+		TaskCategories: opts.TaskCategory,
+		SizeCategories: opts.SizeCategory,
+		Languages:      opts.Language,
+		Limit:          opts.Limit,
+		Sort:           opts.Sort,
+		Direction:      opts.Direction,
+		Token:          token,
+		Full:           opts.Full,
+	}
+
+	format, err := listFormat(opts.OutputFormat, opts.Template)
+	if err != nil {
+		return err
+	}
+	columnNames := utils.DefaultDatasetColumns
+	if len(opts.Columns) > 0 {
+		columnNames = opts.Columns
+	}
+	columns, err := utils.SelectColumns(utils.DatasetColumnsWith(numberFormat(opts.Human)), columnNames)
+	if err != nil {
+		return err
+	}
+
+	pager := client.DatasetPagesContext(cmd.Context(), apiOpts)
+	return writeListing(cmd, format, pager, func(datasets []models.Dataset) utils.Table {
+		return utils.DatasetTableColumns(datasets, columns)
+	}, "datasets")
+}
@@ -0,0 +1,114 @@
+package cli
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestListDatasetsFormats(t *testing.T) {
+	var query string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/datasets" {
+			http.NotFound(w, r)
+			return
+		}
+		query = r.URL.RawQuery
+		w.Write([]byte(`[{"id":"org/data","downloads":1234,"likes":5,"lastModified":"2024-05-01T12:00:00Z",
+			"tags":["task_categories:text-classification","size_categories:10K<n<100K","language:en"]}]`))
+	}))
+	t.Cleanup(server.Close)
+	t.Setenv("HF_TOKEN", "")
+
+	tests := []struct {
+		name string
+		args []string
+		want string
+	}{
+		{"json", []string{"--output-format", "json"}, `[
+  {
+    "id": "org/data",
+    "author": "org",
+    "downloads": 1234,
+    "likes": 5,
+    "lastModified": "2024-05-01T12:00:00Z",
+    "private": false,
+    "tags": [
+      "task_categories:text-classification",
+      "size_categories:10K<n<100K",
+      "language:en"
+    ]
+  }
+]
+`},
+		{"csv", []string{"--output-format", "csv", "--columns", "id,downloads,size_categories"},
+			"Dataset ID,Downloads,Size\norg/data,1234,10K<n<100K\n"},
+		{"tsv", []string{"--output-format", "tsv", "--columns", "id,likes"}, "Dataset ID\tLikes\norg/data\t5\n"},
+		{"ndjson", []string{"--output-format", "ndjson", "--columns", "id"},
+			`{"id":"org/data","author":"org","downloads":1234,"likes":5,"lastModified":"2024-05-01T12:00:00Z","private":false,` +
+				`"tags":["task_categories:text-classification","size_categories:10K<n<100K","language:en"]}` + "\n"},
+		{"template", []string{"--template", "{{.ID}} {{number .Downloads}} {{join .SizeCategories \"|\"}}"}, "org/data 1,234 10K<n<100K\n"},
+		{"human table", []string{"--human", "--columns", "id,downloads"}, "1.23K"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := NewRootCmd()
+			var out strings.Builder
+			cmd.SetOut(&out)
+			cmd.SetArgs(append([]string{"--endpoint", server.URL, "list-datasets", "--size-category", "10K<n<100K"}, tt.args...))
+			if err := cmd.Execute(); err != nil {
+				t.Fatalf("list-datasets error: %v", err)
+			}
+
+			if strings.HasPrefix(tt.name, "human") {
+				if !strings.Contains(out.String(), tt.want) {
+					t.Errorf("output does not contain %q:\n%s", tt.want, out.String())
+				}
+			} else if out.String() != tt.want {
+				t.Errorf("got:\n%s\nwant:\n%s", out.String(), tt.want)
+			}
+			if !strings.Contains(query, "filter=size_categories%3A10K%3Cn%3C100K") {
+				t.Errorf("query %q does not filter on the size category", query)
+			}
+		})
+	}
+
+	cmd := NewRootCmd()
+	cmd.SetOut(&strings.Builder{})
+	cmd.SetErr(&strings.Builder{})
+	cmd.SetArgs([]string{"--endpoint", server.URL, "list-datasets", "--output-format", "xml"})
+	if err := cmd.Execute(); err == nil || !strings.Contains(err.Error(), "xml") {
+		t.Errorf("list-datasets with an unknown format = %v, want an error naming it", err)
+	}
+}
+
+func TestDatasetInfoJSON(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/datasets/org/data" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write([]byte(`{"id":"org/data","tags":["size_categories:10K<n<100K","a&b"]}`))
+	}))
+	t.Cleanup(server.Close)
+	t.Setenv("HF_TOKEN", "")
+
+	cmd := NewRootCmd()
+	var out strings.Builder
+	cmd.SetOut(&out)
+	cmd.SetArgs([]string{"--endpoint", server.URL, "dataset-info", "--output-format", "json", "org/data"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("dataset-info error: %v", err)
+	}
+
+	// Like the json output format of the listings, HTML characters aren't escaped
+	for _, want := range []string{`"size_categories:10K<n<100K"`, `"a&b"`, `"author": "org"`} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("output does not contain %s:\n%s", want, out.String())
+		}
+	}
+	if !strings.HasSuffix(out.String(), "}\n") {
+		t.Errorf("output does not end with a newline:\n%q", out.String())
+	}
+}
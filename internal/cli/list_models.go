@@ -1,9 +1,10 @@
 package cli
 
 import (
+	"context"
 	"fmt"
-	"os"
 
+	hfmodels "github.com/Megatherium/hf-go"
 	"github.com/Megatherium/hf-go/internal/api"
 	"github.com/Megatherium/hf-go/internal/models"
 	"github.com/Megatherium/hf-go/internal/pkg/utils"
@@ -13,15 +14,22 @@ import (
 // ListModelsOptions holds the CLI flags for the list-models command
 type ListModelsOptions struct {
 	Search       string
-	Filter       string
+	Filter       []string
 	Author       string
-	PipelineTag  string
-	LibraryName  string
-	Language     string
-	Tag          string
+	PipelineTag  []string
+	LibraryName  []string
+	Language     []string
+	Tag          []string
+	MatchAny     bool
 	Limit        int
 	Sort         string
 	Direction    int
+	Expand       []string
+	Full         bool
+	Where        string
+	Columns      []string
+	Template     string
+	Human        bool
 	OutputFormat string
 	Token        string
 }
@@ -35,6 +43,28 @@ func NewListModelsCmd() *cobra.Command {
 		Short: "List models from the Hugging Face Hub",
 		Long: `List models from the Hugging Face Hub with various filters and output formats.
 
+--where filters the models returned by the Hub on the client. Fields are
+compared with =, !=, >, >=, <, <=, ~ (contains), "in (a, b)" and, for dates,
+"within 90d"; a bare word is a tag or a boolean field (private, gated).
+Terms combine with and, or, not and parentheses. Fields: id, author,
+downloads, downloadsAllTime, likes, lastModified, createdAt, library, task,
+private, gated, trendingScore, tags, license, parameters, sha, baseModel.
+--limit counts the matching models.
+
+--filter, --tag, --language, --library-name and --pipeline-tag can be
+repeated; models must have all the values, or with --match-any at least one
+value of each repeated flag.
+
+--columns picks the columns of the table, csv, tsv and markdown output: id,
+author, downloads, likes, last_modified, library, task, trending_score,
+private, gated, created_at, downloads_all_time, license, parameters, sha
+and tags. --template prints every model with a Go template over the Model
+fields, with the join, number, date and json functions.
+
+On a terminal the table is fitted to its width, ellipsizing the widest
+columns, and gated (yellow) and private (magenta) models are coloured,
+unless --no-color or NO_COLOR is set. Piped output stays plain.
+
 Examples:
   # List all models (limited to 20 by default)
   hf-go list-models
@@ -48,99 +78,135 @@ Examples:
   # Filter by pipeline tag
   hf-go list-models --pipeline-tag text-classification
 
+  # Repeated filters must all match: English GGUF text generation models
+  hf-go list-models --tag gguf --pipeline-tag text-generation --language en
+
+  # ...or any of them with --match-any: GGUF or MLX models
+  hf-go list-models --tag gguf --tag mlx --match-any
+
   # JSON output for machine processing
   hf-go list-models --search bert --output-format json
 
+  # CSV for spreadsheets, NDJSON streamed one model per line
+  hf-go list-models --author google --output-format csv
+  hf-go list-models --author google --limit 0 --output-format ndjson | jq .id
+
+  # Pick and order the table columns
+  hf-go list-models --sort trending_score --columns id,downloads,likes,trending_score,gated,private
+
+  # One line per model with a Go template
+  hf-go list-models --author google --template '{{.ID}} {{.Downloads}}'
+
+  # Compact counts (1.23M) and no colours
+  hf-go list-models --sort downloads --human --no-color
+
   # Limit results and sort by downloads
   hf-go list-models --limit 10 --sort downloads
+
+  # Filter on the client: popular permissively licensed GGUF models updated recently
+  hf-go list-models --search llama --where 'downloads > 10k and license in (apache-2.0, mit) and lastModified within 90d and gguf'
+
+  # Include the model card, parameter counts and files in the JSON output
+  hf-go list-models --author Qwen --full --output-format json
 `,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return runListModels(opts)
+			return runListModels(cmd, opts)
 		},
 	}
 
 	// Add flags
 	cmd.Flags().StringVar(&opts.Search, "search", "", "Search for models with this string in their id")
-	cmd.Flags().StringVar(&opts.Filter, "filter", "", "Filter models by library, task, or tags")
+	cmd.Flags().StringArrayVar(&opts.Filter, "filter", nil, "Filter models by library, task, or tags (repeatable)")
 	cmd.Flags().StringVar(&opts.Author, "author", "", "Filter models by author (username or organization)")
-	cmd.Flags().StringVar(&opts.PipelineTag, "pipeline-tag", "", "Filter models by pipeline tag (e.g., 'text-generation')")
-	cmd.Flags().StringVar(&opts.LibraryName, "library-name", "", "Filter models by library (e.g., 'pytorch', 'tensorflow')")
-	cmd.Flags().StringVar(&opts.Language, "language", "", "Filter models by language (e.g., 'en', 'fr')")
-	cmd.Flags().StringVar(&opts.Tag, "tag", "", "Filter models by specific tag")
+	cmd.Flags().StringArrayVar(&opts.PipelineTag, "pipeline-tag", nil, "Filter models by pipeline tag (e.g., 'text-generation') (repeatable)")
+	cmd.Flags().StringArrayVar(&opts.LibraryName, "library-name", nil, "Filter models by library (e.g., 'pytorch', 'tensorflow') (repeatable)")
+	cmd.Flags().StringArrayVar(&opts.Language, "language", nil, "Filter models by language (e.g., 'en', 'fr') (repeatable)")
+	cmd.Flags().StringArrayVar(&opts.Tag, "tag", nil, "Filter models by specific tag (repeatable)")
+	cmd.Flags().BoolVar(&opts.MatchAny, "match-any", false, "Match any of the repeated values of a filter instead of all of them (checked on the client)")
 	cmd.Flags().IntVar(&opts.Limit, "limit", 20, "Maximum number of models to return")
 	cmd.Flags().StringVar(&opts.Sort, "sort", "", "Sort results by field (e.g., 'downloads', 'likes', 'trending_score')")
 	cmd.Flags().IntVar(&opts.Direction, "direction", 0, "Sort direction: -1 for descending, 1 for ascending")
-	cmd.Flags().StringVar(&opts.OutputFormat, "output-format", "table", "Output format: 'table' or 'json'")
+	cmd.Flags().StringSliceVar(&opts.Expand, "expand", nil, "Additional properties to fetch, e.g. 'cardData,safetensors,gguf,siblings'")
+	cmd.Flags().BoolVar(&opts.Full, "full", false, "Fetch every model property (tags, files, model card, parameter counts...)")
+	cmd.Flags().StringVar(&opts.Where, "where", "", "Filter models on the client, e.g. 'downloads > 10k and not gated'")
+	cmd.Flags().StringSliceVar(&opts.Columns, "columns", nil, "Columns of the table, csv, tsv and markdown output, e.g. 'id,downloads,likes,trending_score,gated,private'")
+	cmd.Flags().BoolVar(&opts.Human, "human", false, "Show counts in compact form (1.23M) instead of with thousands separators")
+	cmd.Flags().StringVar(&opts.Template, "template", "", "Print every model with a Go template instead, e.g. '{{.ID}} {{.Downloads}}'")
+	cmd.Flags().StringVar(&opts.OutputFormat, "output-format", "table", "Output format: table, json, ndjson, yaml, csv, tsv or markdown")
 	cmd.Flags().StringVar(&opts.Token, "token", "", "Hugging Face API token (optional, can also use HF_TOKEN env var)")
 
 	return cmd
 }
 
 // runListModels executes the list-models command
-func runListModels(opts *ListModelsOptions) error {
-	// Get token from environment if not provided
-	token := opts.Token
-	if token == "" {
-		token = os.Getenv("HF_TOKEN")
-	}
-
-	// Create API client
-	client := api.NewClient(token)
+func runListModels(cmd *cobra.Command, opts *ListModelsOptions) error {
+	token := resolveToken(opts.Token)
+	client := newAPIClient(cmd, token)
 
 	// Build API options
 	apiOpts := models.ListModelsOptions{
-		Search:      opts.Search,
-		Filter:      opts.Filter,
-		Author:      opts.Author,
-		PipelineTag: opts.PipelineTag,
-		LibraryName: opts.LibraryName,
-		Language:    opts.Language,
-		Tag:         opts.Tag,
-		Limit:       opts.Limit,
-		Sort:        opts.Sort,
-		Direction:   opts.Direction,
-		Token:       token,
+		Search:       opts.Search,
+		Filters:      opts.Filter,
+		Author:       opts.Author,
+		PipelineTags: opts.PipelineTag,
+		Libraries:    opts.LibraryName,
+		Languages:    opts.Language,
+		Tags:         opts.Tag,
+		MatchAny:     opts.MatchAny,
+		Limit:        opts.Limit,
+		Sort:         opts.Sort,
+		Direction:    opts.Direction,
+		Token:        token,
+		Expand:       opts.Expand,
+		Full:         opts.Full,
+	}
+	if opts.Where != "" {
+		expr, err := hfmodels.ParseFilter(opts.Where)
+		if err != nil {
+			return err
+		}
+		apiOpts = hfmodels.FilterModels(apiOpts, expr)
 	}
 
-	// Fetch models
-	modelsList, err := client.ListModels(apiOpts)
+	format, err := listFormat(opts.OutputFormat, opts.Template)
 	if err != nil {
-		return fmt.Errorf("failed to list models: %w", err)
+		return err
 	}
-
-	// Format output
-	switch opts.OutputFormat {
-	case "json":
-		output, err := utils.FormatJSON(modelsList)
-		if err != nil {
-			return fmt.Errorf("failed to format JSON: %w", err)
-		}
-		fmt.Println(output)
-	case "table":
-		output := utils.FormatTable(modelsList)
-		fmt.Println(output)
-	default:
-		return fmt.Errorf("unsupported output format: %s (use 'table' or 'json')", opts.OutputFormat)
+	columnNames := utils.DefaultModelColumns
+	if len(opts.Columns) > 0 {
+		columnNames = opts.Columns
+		// Columns of expanded properties, such as the license, need them fetched
+		apiOpts.Expand = append(apiOpts.Expand, models.FieldExpand(columnNames...)...)
+	}
+	columns, err := utils.SelectColumns(utils.ModelColumnsWith(numberFormat(opts.Human)), columnNames)
+	if err != nil {
+		return err
 	}
 
-	return nil
+	pager := client.ModelPagesContext(cmd.Context(), apiOpts)
+	return writeListing(cmd, format, pager, func(modelsList []models.Model) utils.Table {
+		return utils.ModelTableColumns(modelsList, columns)
+	}, "models")
 }
 
-// ListModels is a public function that can be used as a library
+// ListModels is a public function that can be used as a library. format is
+// any registered output format: table, json, ndjson, yaml, csv, tsv or
+// markdown.
 func ListModels(opts models.ListModelsOptions, format string) (string, error) {
+	return ListModelsContext(context.Background(), opts, format)
+}
+
+// ListModelsContext is like ListModels but the requests are bound to ctx
+func ListModelsContext(ctx context.Context, opts models.ListModelsOptions, format string) (string, error) {
+	if _, err := utils.LookupFormat(format); err != nil {
+		return "", err
+	}
 	client := api.NewClient(opts.Token)
 
-	modelsList, err := client.ListModels(opts)
+	modelsList, err := client.ListModelsContext(ctx, opts)
 	if err != nil {
 		return "", fmt.Errorf("failed to list models: %w", err)
 	}
 
-	switch format {
-	case "json":
-		return utils.FormatJSON(modelsList)
-	case "table":
-		return utils.FormatTable(modelsList), nil
-	default:
-		return "", fmt.Errorf("unsupported output format: %s", format)
-	}
+	return utils.FormatModels(modelsList, format)
 }
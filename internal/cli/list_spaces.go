@@ -0,0 +1,143 @@
+package cli
+
+import (
+	"slices"
+
+	hfmodels "github.com/Megatherium/hf-go"
+	"github.com/Megatherium/hf-go/internal/models"
+	"github.com/Megatherium/hf-go/internal/pkg/utils"
+	"github.com/spf13/cobra"
+)
+
+// ListSpacesOptions holds the CLI flags for the list-spaces command
+type ListSpacesOptions struct {
+	Search       string
+	Author       string
+	Filter       []string
+	Tag          []string
+	Model        []string
+	Dataset      []string
+	Linked       bool
+	Runtime      bool
+	Limit        int
+	Sort         string
+	Direction    int
+	Columns      []string
+	Template     string
+	Human        bool
+	OutputFormat string
+	Token        string
+}
+
+// NewListSpacesCmd creates the list-spaces command
+func NewListSpacesCmd() *cobra.Command {
+	opts := &ListSpacesOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "list-spaces",
+		Short: "List Spaces from the Hugging Face Hub",
+		Long: `List Spaces from the Hugging Face Hub with various filters and output formats.
+
+--model lists the Spaces using a model: the Spaces whose models field
+contains the repository ID. --dataset does the same for datasets. Both, like
+--filter and --tag, can be repeated; Spaces must match all the values.
+
+--linked adds the models and datasets every Space uses, --runtime its stage
+(RUNNING, SLEEPING, BUILDING...) and hardware.
+
+--columns picks the columns of the table, csv, tsv and markdown output: id,
+author, likes, sdk, stage, hardware, last_modified, models, datasets,
+trending_score, private, created_at and tags; the models, datasets, stage
+and hardware columns fetch what they show. --template prints every Space
+with a Go template over the Space fields.
+
+Examples:
+  # The most liked Spaces
+  hf-go list-spaces --sort likes --direction -1
+
+  # Spaces using a model, with their stage and hardware
+  hf-go list-spaces --model meta-llama/Llama-3.1-8B-Instruct --runtime
+
+  # Gradio Spaces of an organization as CSV
+  hf-go list-spaces --author huggingface --filter gradio --output-format csv
+
+  # One line per Space with a Go template
+  hf-go list-spaces --search chat --linked --template '{{.ID}} {{join .Models ","}}'
+`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runListSpaces(cmd, opts)
+		},
+	}
+
+	// Add flags
+	cmd.Flags().StringVar(&opts.Search, "search", "", "Search for Spaces with this string in their id")
+	cmd.Flags().StringVar(&opts.Author, "author", "", "Filter Spaces by author (username or organization)")
+	cmd.Flags().StringArrayVar(&opts.Filter, "filter", nil, "Filter Spaces by tag as the Hub writes it, e.g. 'gradio' (repeatable)")
+	cmd.Flags().StringArrayVar(&opts.Tag, "tag", nil, "Filter Spaces by specific tag (repeatable)")
+	cmd.Flags().StringArrayVar(&opts.Model, "model", nil, "List the Spaces using this model (repeatable)")
+	cmd.Flags().StringArrayVar(&opts.Dataset, "dataset", nil, "List the Spaces using this dataset (repeatable)")
+	cmd.Flags().BoolVar(&opts.Linked, "linked", false, "Fetch the models and datasets every Space uses")
+	cmd.Flags().BoolVar(&opts.Runtime, "runtime", false, "Fetch the stage and hardware of every Space")
+	cmd.Flags().IntVar(&opts.Limit, "limit", 20, "Maximum number of Spaces to return")
+	cmd.Flags().StringVar(&opts.Sort, "sort", "", "Sort results by field (e.g., 'likes', 'trending_score', 'lastModified')")
+	cmd.Flags().IntVar(&opts.Direction, "direction", 0, "Sort direction: -1 for descending, 1 for ascending")
+	cmd.Flags().StringSliceVar(&opts.Columns, "columns", nil, "Columns of the table, csv, tsv and markdown output, e.g. 'id,likes,sdk,hardware'")
+	cmd.Flags().BoolVar(&opts.Human, "human", false, "Show counts in compact form (1.23M) instead of with thousands separators")
+	cmd.Flags().StringVar(&opts.Template, "template", "", "Print every Space with a Go template instead, e.g. '{{.ID}} {{.SDK}}'")
+	cmd.Flags().StringVar(&opts.OutputFormat, "output-format", "table", "Output format: table, json, ndjson, yaml, csv, tsv or markdown")
+	cmd.Flags().StringVar(&opts.Token, "token", "", "Hugging Face API token (optional, can also use HF_TOKEN env var)")
+
+	return cmd
+}
+
+// runListSpaces executes the list-spaces command
+func runListSpaces(cmd *cobra.Command, opts *ListSpacesOptions) error {
+	token := resolveToken(opts.Token)
+	client := newAPIClient(cmd, token)
+
+	apiOpts := models.ListSpacesOptions{
+		Search:    opts.Search,
+		Author:    opts.Author,
+		Filters:   opts.Filter,
+		Tags:      opts.Tag,
+		Datasets:  opts.Dataset,
+		Linked:    opts.Linked,
+		Runtime:   opts.Runtime,
+		Limit:     opts.Limit,
+		Sort:      opts.Sort,
+		Direction: opts.Direction,
+		Token:     token,
+	}
+	for _, model := range opts.Model {
+		apiOpts = hfmodels.SpacesUsingModelOptions(apiOpts, model)
+	}
+
+	format, err := listFormat(opts.OutputFormat, opts.Template)
+	if err != nil {
+		return err
+	}
+	columnNames := utils.DefaultSpaceColumns
+	if opts.Runtime {
+		columnNames = append(append([]string{}, columnNames...), utils.SpaceRuntimeColumns...)
+	}
+	if len(opts.Columns) > 0 {
+		columnNames = opts.Columns
+		// The models and datasets columns need the linked listing, the stage
+		// and hardware columns the runtime
+		if slices.Contains(columnNames, "models") || slices.Contains(columnNames, "datasets") {
+			apiOpts.Linked = true
+		}
+		if slices.Contains(columnNames, "stage") || slices.Contains(columnNames, "hardware") {
+			apiOpts.Runtime = true
+		}
+	}
+	columns, err := utils.SelectColumns(utils.SpaceColumnsWith(numberFormat(opts.Human)), columnNames)
+	if err != nil {
+		return err
+	}
+
+	pager := client.SpacePagesContext(cmd.Context(), apiOpts)
+	return writeListing(cmd, format, pager, func(spaces []models.Space) utils.Table {
+		return utils.SpaceTableColumns(spaces, columns)
+	}, "Spaces")
+}
@@ -0,0 +1,52 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/Megatherium/hf-go/internal/api"
+	"github.com/Megatherium/hf-go/internal/pkg/utils"
+	"github.com/spf13/cobra"
+)
+
+// listFormat returns the template format if a template is given, else the
+// named output format
+func listFormat(name, tmpl string) (utils.Format, error) {
+	if tmpl != "" {
+		return utils.TemplateFormat(tmpl)
+	}
+	return utils.LookupFormat(name)
+}
+
+// numberFormat returns the compact number style if human is set, else the
+// comma style
+func numberFormat(human bool) utils.NumberFormat {
+	if human {
+		return utils.CompactNumber
+	}
+	return utils.CommaNumber
+}
+
+// writeListing writes the records of a listing to stdout in format: page by
+// page as the pages arrive for streaming formats, else all at once. table
+// builds the table of a page; what names the records in errors.
+func writeListing[T any](cmd *cobra.Command, format utils.Format, pager *api.Pager[T], table func([]T) utils.Table, what string) error {
+	out := cmd.OutOrStdout()
+
+	if format.Streaming {
+		for pager.Next() {
+			if err := format.Write(out, fitTerminal(cmd, table(pager.Page()))); err != nil {
+				return err
+			}
+		}
+		if err := pager.Err(); err != nil {
+			return fmt.Errorf("failed to list %s: %w", what, err)
+		}
+		return nil
+	}
+
+	records, err := pager.All()
+	if err != nil {
+		return fmt.Errorf("failed to list %s: %w", what, err)
+	}
+	return format.Write(out, fitTerminal(cmd, table(records)))
+}
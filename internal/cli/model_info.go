@@ -0,0 +1,143 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	hfmodels "github.com/Megatherium/hf-go"
+	"github.com/Megatherium/hf-go/internal/pkg/utils"
+	"github.com/spf13/cobra"
+)
+
+// ModelInfoOptions holds the CLI flags for the model-info command
+type ModelInfoOptions struct {
+	Revision     string
+	OutputFormat string
+	Token        string
+}
+
+// NewModelInfoCmd creates the model-info command
+func NewModelInfoCmd() *cobra.Command {
+	opts := &ModelInfoOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "model-info <model>",
+		Short: "Show the details of a model",
+		Long: `Show the details of a model: author, license, base model, task, library,
+tags, GGUF information, access control and the files of the repository with
+their sizes.
+
+Examples:
+  # Details of a model
+  hf-go model-info google-bert/bert-base-uncased
+
+  # Details as of a tag, as JSON
+  hf-go model-info --revision v1.0 --output-format json org/model
+`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runModelInfo(cmd, args[0], opts)
+		},
+	}
+
+	// Add flags
+	cmd.Flags().StringVar(&opts.Revision, "revision", "", "Branch, tag or commit hash (default 'main')")
+	cmd.Flags().StringVar(&opts.OutputFormat, "output-format", "table", "Output format: 'table' or 'json'")
+	cmd.Flags().StringVar(&opts.Token, "token", "", "Hugging Face API token (optional, can also use HF_TOKEN env var)")
+
+	return cmd
+}
+
+// runModelInfo executes the model-info command
+func runModelInfo(cmd *cobra.Command, modelID string, opts *ModelInfoOptions) error {
+	if opts.OutputFormat != "table" && opts.OutputFormat != "json" {
+		return fmt.Errorf("unsupported output format: %s (use 'table' or 'json')", opts.OutputFormat)
+	}
+
+	client := newClient(cmd, resolveToken(opts.Token))
+
+	details, err := client.GetModelDetailsWithOptions(cmd.Context(), modelID, hfmodels.ModelDetailsOptions{
+		Revision: opts.Revision,
+		Blobs:    true,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to get model details: %w", err)
+	}
+
+	if opts.OutputFormat == "json" {
+		return printJSON(cmd, details)
+	}
+	printModelDetails(cmd.OutOrStdout(), details)
+	return nil
+}
+
+// printModelDetails prints the details of a model followed by its files
+func printModelDetails(out io.Writer, d *hfmodels.ModelDetails) {
+	author := d.Author
+	if author == "" {
+		author, _, _ = strings.Cut(d.ID, "/")
+	}
+
+	rows := [][]string{
+		{"ID", d.ID},
+		{"Author", author},
+		{"Commit", d.SHA},
+		{"License", utils.OrNA(d.CardData.GetLicense())},
+		{"Base Model", utils.OrNA(d.CardData.GetBaseModel())},
+		{"Task", utils.OrNA(d.PipelineTag)},
+		{"Library", utils.OrNA(d.LibraryName)},
+		{"Downloads", utils.CommaNumber(d.Downloads)},
+		{"Likes", utils.CommaNumber(d.Likes)},
+		{"Last Modified", utils.OrNA(utils.FormatDate(d.LastModified))},
+		{"Gated", formatGated(d.Gated)},
+		{"Private", strconv.FormatBool(d.Private)},
+	}
+	if g := d.GGUFInfo; g != nil {
+		rows = append(rows,
+			[]string{"GGUF Architecture", utils.OrNA(g.Architecture)},
+			[]string{"GGUF Context Length", strconv.Itoa(g.ContextLength)},
+			[]string{"GGUF Parameters", formatParameters(uint64(max(g.Total, 0)))},
+		)
+	}
+	if quants := hfmodels.ExtractQuantsFromSiblings(d.Siblings); len(quants) > 0 {
+		rows = append(rows, []string{"Quants", strings.Join(quants, ", ")})
+	}
+	rows = append(rows, []string{"Tags", utils.OrNA(strings.Join(d.Tags, ", "))})
+	fmt.Fprintln(out, utils.RenderTable([]string{"Field", "Value"}, rows))
+
+	printFiles(out, d.Siblings)
+}
+
+// printFiles prints the files of a repository with their sizes and a total
+func printFiles(out io.Writer, siblings []hfmodels.Sibling) {
+	if len(siblings) == 0 {
+		return
+	}
+
+	var total int64
+	files := make([][]string, len(siblings))
+	for i, s := range siblings {
+		lfs := ""
+		if s.LFS != nil {
+			lfs = "yes"
+		}
+		files[i] = []string{s.RFilename, utils.FormatBytes(s.Size), lfs}
+		total += s.Size
+	}
+	fmt.Fprintln(out)
+	fmt.Fprintln(out, utils.RenderTable([]string{"File", "Size", "LFS"}, files))
+	fmt.Fprintf(out, "%d files, %s\n", len(siblings), utils.FormatBytes(total))
+}
+
+// formatGated formats the access control of a repository
+func formatGated(g hfmodels.Gated) string {
+	if !g.IsGated() {
+		return "no"
+	}
+	if g == "true" {
+		return "yes"
+	}
+	return "yes (" + string(g) + " approval)"
+}
@@ -0,0 +1,161 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"time"
+
+	hfmodels "github.com/Megatherium/hf-go"
+	"github.com/Megatherium/hf-go/internal/pkg/utils"
+)
+
+// progressInterval throttles redraws of the terminal progress display
+const progressInterval = 100 * time.Millisecond
+
+// progressBarWidth is the number of cells of a progress bar
+const progressBarWidth = 20
+
+// fileProgress is the state of one file of a download
+type fileProgress struct {
+	name       string
+	downloaded int64
+	total      int64
+	done       bool
+}
+
+// progressDisplay reports the progress of a multi-file download. On a
+// terminal it redraws one line per file in flight below the completed ones;
+// otherwise it logs a line when each file starts and completes.
+type progressDisplay struct {
+	mu     sync.Mutex
+	out    io.Writer
+	tty    bool
+	files  map[string]*fileProgress
+	active []*fileProgress
+	count  int
+	size   int64
+	done   int
+	lines  int
+	drawn  time.Time
+}
+
+// newProgressDisplay creates a progress display writing to out
+func newProgressDisplay(out io.Writer) *progressDisplay {
+	return &progressDisplay{
+		out:   out,
+		tty:   utils.IsTerminal(out),
+		files: make(map[string]*fileProgress),
+	}
+}
+
+// start records the files about to be downloaded
+func (p *progressDisplay) start(files []hfmodels.Sibling) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.count = len(files)
+	for _, f := range files {
+		p.size += f.Size
+	}
+	if !p.tty {
+		fmt.Fprintf(p.out, "Fetching %d files (%s)\n", p.count, utils.FormatBytes(p.size))
+	}
+}
+
+// update records the progress of a file; it is a hfmodels.SnapshotProgressFunc
+func (p *progressDisplay) update(name string, downloaded, total int64) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	f, ok := p.files[name]
+	if !ok {
+		f = &fileProgress{name: name}
+		p.files[name] = f
+		p.active = append(p.active, f)
+		if !p.tty && downloaded < total {
+			fmt.Fprintf(p.out, "Downloading %s (%s)\n", name, utils.FormatBytes(total))
+		}
+	}
+	if f.done {
+		return
+	}
+	f.downloaded, f.total = downloaded, total
+
+	if downloaded == total {
+		f.done = true
+		p.done++
+		if !p.tty {
+			fmt.Fprintf(p.out, "Downloaded %s (%s) [%d/%d]\n", name, utils.FormatBytes(total), p.done, p.count)
+			return
+		}
+		p.redraw(f)
+		return
+	}
+
+	if p.tty && time.Since(p.drawn) >= progressInterval {
+		p.redraw(nil)
+	}
+}
+
+// redraw clears the previous in-flight lines, prints completed as a permanent
+// line if set, then the files in flight
+func (p *progressDisplay) redraw(completed *fileProgress) {
+	var b strings.Builder
+	if p.lines > 0 {
+		fmt.Fprintf(&b, "\x1b[%dA\x1b[J", p.lines)
+	}
+	if completed != nil {
+		fmt.Fprintf(&b, "✓ %s (%s)\n", completed.name, utils.FormatBytes(completed.total))
+	}
+
+	active := p.active[:0]
+	for _, f := range p.active {
+		if !f.done {
+			active = append(active, f)
+		}
+	}
+	p.active = active
+
+	for _, f := range p.active {
+		b.WriteString(progressLine(f))
+		b.WriteByte('\n')
+	}
+	p.lines = len(p.active)
+	if p.lines > 0 {
+		var downloaded int64
+		for _, f := range p.files {
+			downloaded += f.downloaded
+		}
+		fmt.Fprintf(&b, "[%d/%d files, %s / %s]\n", p.done, p.count, utils.FormatBytes(downloaded), utils.FormatBytes(p.size))
+		p.lines++
+	}
+
+	io.WriteString(p.out, b.String())
+	p.drawn = time.Now()
+}
+
+// finish clears the lines of the files in flight
+func (p *progressDisplay) finish() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.tty && p.lines > 0 {
+		fmt.Fprintf(p.out, "\x1b[%dA\x1b[J", p.lines)
+		p.lines = 0
+	}
+}
+
+// progressLine renders the progress bar of a file in flight
+func progressLine(f *fileProgress) string {
+	if f.total <= 0 {
+		return fmt.Sprintf("  %s %s", f.name, utils.FormatBytes(f.downloaded))
+	}
+
+	// The server may report less than it sends, so the bar can't overflow
+	filled := min(max(int(int64(progressBarWidth)*f.downloaded/f.total), 0), progressBarWidth)
+	bar := strings.Repeat("█", filled) + strings.Repeat("░", progressBarWidth-filled)
+	return fmt.Sprintf("  %s %s %3d%% %s / %s", bar, f.name,
+		100*f.downloaded/f.total, utils.FormatBytes(f.downloaded), utils.FormatBytes(f.total))
+}
@@ -0,0 +1,38 @@
+package cli
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestProgressLine(t *testing.T) {
+	tests := []struct {
+		name       string
+		downloaded int64
+		total      int64
+		want       string
+	}{
+		{"start", 0, 1000, "  ░░░░░░░░░░░░░░░░░░░░ model.gguf   0% 0 B / 1000 B"},
+		{"half", 500, 1000, "  ██████████░░░░░░░░░░ model.gguf  50% 500 B / 1000 B"},
+		{"done", 1000, 1000, "  ████████████████████ model.gguf 100% 1000 B / 1000 B"},
+		{"unknown size", 2048, 0, "  model.gguf 2.00 KiB"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := progressLine(&fileProgress{name: "model.gguf", downloaded: tt.downloaded, total: tt.total})
+			if got != tt.want {
+				t.Errorf("progressLine = %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	// More bytes than the server announced, or a negative count, must not
+	// overflow the bar
+	for _, downloaded := range []int64{1500, -10} {
+		got := progressLine(&fileProgress{name: "model.gguf", downloaded: downloaded, total: 1000})
+		bar := strings.Fields(got)[0]
+		if n := len([]rune(bar)); n != progressBarWidth {
+			t.Errorf("progressLine with %d of 1000 bytes has a bar of %d cells: %q", downloaded, n, got)
+		}
+	}
+}
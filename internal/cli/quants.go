@@ -0,0 +1,190 @@
+package cli
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	hfmodels "github.com/Megatherium/hf-go"
+	"github.com/Megatherium/hf-go/internal/pkg/utils"
+	"github.com/spf13/cobra"
+)
+
+// QuantsOptions holds the CLI flags for the quants command
+type QuantsOptions struct {
+	Fit           string
+	ContextLength int
+	KVCacheBytes  float64
+	Revision      string
+	OutputFormat  string
+	Token         string
+}
+
+// NewQuantsCmd creates the quants command
+func NewQuantsCmd() *cobra.Command {
+	opts := &QuantsOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "quants <model>",
+		Short: "List the GGUF quantizations of a model and pick one for a memory budget",
+		Long: `List the GGUF quant variants of a model with their real file sizes.
+
+With --fit, variants are ranked by expected quality and the largest one that
+fits the budget is recommended. The memory needed by each variant is its file
+size plus a KV cache estimated from the GGUF architecture metadata at the
+context length given by --ctx, plus a fixed overhead for compute buffers.
+
+Examples:
+  # List the quant variants of a model
+  hf-go quants unsloth/Qwen3-8B-GGUF
+
+  # Pick the best quant for 24 GiB of VRAM at 8k context
+  hf-go quants --fit 24GiB --ctx 8192 unsloth/Qwen3-32B-GGUF
+`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runQuants(cmd, args[0], opts)
+		},
+	}
+
+	// Add flags
+	cmd.Flags().StringVar(&opts.Fit, "fit", "", "Memory budget to fit, e.g. '24GiB', '16GB' or '8G'")
+	cmd.Flags().IntVar(&opts.ContextLength, "ctx", hfmodels.DefaultFitContextLength, "Context length used to size the KV cache")
+	cmd.Flags().Float64Var(&opts.KVCacheBytes, "kv-bytes", hfmodels.DefaultKVCacheBytes, "Bytes per KV cache element: 2 for f16, 1 for q8_0")
+	cmd.Flags().StringVar(&opts.Revision, "revision", "", "Branch, tag or commit hash (default 'main')")
+	cmd.Flags().StringVar(&opts.OutputFormat, "output-format", "table", "Output format: 'table' or 'json'")
+	cmd.Flags().StringVar(&opts.Token, "token", "", "Hugging Face API token (optional, can also use HF_TOKEN env var)")
+
+	return cmd
+}
+
+// runQuants executes the quants command
+func runQuants(cmd *cobra.Command, modelID string, opts *QuantsOptions) error {
+	if opts.OutputFormat != "table" && opts.OutputFormat != "json" {
+		return fmt.Errorf("unsupported output format: %s (use 'table' or 'json')", opts.OutputFormat)
+	}
+
+	client := newClient(cmd, resolveToken(opts.Token))
+
+	if opts.Fit == "" {
+		variants, err := client.GetQuantVariantsContext(cmd.Context(), modelID, opts.Revision)
+		if err != nil {
+			return fmt.Errorf("failed to get quants: %w", err)
+		}
+		return printQuantVariants(cmd, variants, opts.OutputFormat)
+	}
+
+	budget, err := utils.ParseSize(opts.Fit)
+	if err != nil {
+		return err
+	}
+
+	rec, err := client.RecommendQuant(cmd.Context(), modelID, hfmodels.FitOptions{
+		Budget:        budget,
+		ContextLength: opts.ContextLength,
+		KVCacheBytes:  opts.KVCacheBytes,
+		Revision:      opts.Revision,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to recommend a quant: %w", err)
+	}
+	return printQuantRecommendation(cmd, rec, opts.OutputFormat)
+}
+
+// printQuantVariants prints the quant variants of a model
+func printQuantVariants(cmd *cobra.Command, variants []hfmodels.QuantVariant, format string) error {
+	if format == "json" {
+		return printJSON(cmd, variants)
+	}
+
+	if len(variants) == 0 {
+		fmt.Fprintln(cmd.OutOrStdout(), "No GGUF quantizations found.")
+		return nil
+	}
+
+	headers := []string{"Quant", "Role", "Family", "Bits/Weight", "Size", "Files"}
+	rows := make([][]string, len(variants))
+	for i, v := range variants {
+		rows[i] = []string{
+			formatQuantName(v.Name),
+			string(v.Role),
+			string(v.Family),
+			formatBitsPerWeight(v.BitsPerWeight),
+			utils.FormatBytes(v.Size),
+			formatQuantFiles(v),
+		}
+	}
+	fmt.Fprintln(cmd.OutOrStdout(), utils.RenderTable(headers, rows))
+	return nil
+}
+
+// printQuantRecommendation prints the ranked quant variants and the pick
+func printQuantRecommendation(cmd *cobra.Command, rec *hfmodels.QuantRecommendation, format string) error {
+	if format == "json" {
+		return printJSON(cmd, rec)
+	}
+
+	out := cmd.OutOrStdout()
+	headers := []string{"Quant", "Family", "Bits/Weight", "Size", "Required", "Fits"}
+	rows := make([][]string, len(rec.Candidates))
+	for i, c := range rec.Candidates {
+		fits := "no"
+		if c.Fits {
+			fits = "yes"
+		}
+		rows[i] = []string{
+			c.Variant.Name,
+			string(c.Variant.Family),
+			formatBitsPerWeight(c.Variant.BitsPerWeight),
+			utils.FormatBytes(c.Variant.Size),
+			utils.FormatBytes(c.Required),
+			fits,
+		}
+	}
+	fmt.Fprintln(out, utils.RenderTable(headers, rows))
+
+	kv := rec.KVCache
+	fmt.Fprintf(out, "KV cache: %s at %d tokens (%s, %d layers, %d KV heads, head dim %d)\n",
+		utils.FormatBytes(kv.Bytes), kv.ContextLength, kv.Architecture, kv.Layers, kv.KVHeads, kv.HeadDim)
+
+	if rec.Best == nil {
+		fmt.Fprintf(out, "No quantization fits in %s.\n", utils.FormatBytes(rec.Budget))
+		return nil
+	}
+	fmt.Fprintf(out, "Recommended: %s (needs %s of %s)\n",
+		rec.Best.Variant.Name, utils.FormatBytes(rec.Best.Required), utils.FormatBytes(rec.Budget))
+	return nil
+}
+
+// formatQuantName formats a quant name, "-" for files without one
+func formatQuantName(name string) string {
+	if name == "" {
+		return "-"
+	}
+	return name
+}
+
+// formatBitsPerWeight formats a nominal bits per weight, "N/A" if unknown
+func formatBitsPerWeight(bpw float64) string {
+	if bpw == 0 {
+		return "N/A"
+	}
+	return strconv.FormatFloat(bpw, 'f', 2, 64)
+}
+
+// formatQuantFiles summarizes the files of a variant
+func formatQuantFiles(v hfmodels.QuantVariant) string {
+	if !v.Split() {
+		return v.Files[0].Filename
+	}
+	shards := fmt.Sprintf("%d of %d shards", len(v.Files), v.Files[0].ShardCount)
+	if dir, _, ok := strings.Cut(v.Files[0].Filename, "/"); ok {
+		return dir + "/ (" + shards + ")"
+	}
+	return shards
+}
+
+// printJSON prints v as indented JSON, like the json output format
+func printJSON(cmd *cobra.Command, v interface{}) error {
+	return utils.WriteJSON(cmd.OutOrStdout(), v)
+}
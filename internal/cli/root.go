@@ -1,9 +1,29 @@
 package cli
 
 import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	hfmodels "github.com/Megatherium/hf-go"
+	"github.com/Megatherium/hf-go/internal/api"
+	"github.com/Megatherium/hf-go/internal/pkg/utils"
 	"github.com/spf13/cobra"
 )
 
+// Names of the global flags
+const (
+	// endpointFlag overrides the Hub endpoint
+	endpointFlag = "endpoint"
+	// noColorFlag disables colours, like the NO_COLOR environment variable
+	noColorFlag = "no-color"
+)
+
 // NewRootCmd creates the root command for the CLI
 func NewRootCmd() *cobra.Command {
 	cmd := &cobra.Command{
@@ -12,13 +32,75 @@ func NewRootCmd() *cobra.Command {
 		Long:  `A CLI tool for interacting with Hugging Face models.`,
 	}
 
+	cmd.PersistentFlags().String(endpointFlag, "", "Hugging Face Hub endpoint or mirror URL (default: $HF_ENDPOINT or "+api.DefaultEndpoint+")")
+	cmd.PersistentFlags().Bool(noColorFlag, false, "Disable colours (also disabled by NO_COLOR and when output is not a terminal)")
+
 	// Add subcommands
 	cmd.AddCommand(NewListModelsCmd())
+	cmd.AddCommand(NewQuantsCmd())
+	cmd.AddCommand(NewModelInfoCmd())
+	cmd.AddCommand(NewInspectCmd())
+	cmd.AddCommand(NewDownloadCmd())
+	cmd.AddCommand(NewListDatasetsCmd())
+	cmd.AddCommand(NewDatasetInfoCmd())
+	cmd.AddCommand(NewListSpacesCmd())
+	cmd.AddCommand(NewSpaceInfoCmd())
 
 	return cmd
 }
 
-// Execute runs the CLI
+// newAPIClient creates an API client honouring the global --endpoint flag
+// and logging retries to stderr
+func newAPIClient(cmd *cobra.Command, token string) *api.Client {
+	client := api.NewClient(token)
+	if endpoint, _ := cmd.Flags().GetString(endpointFlag); endpoint != "" {
+		client.Endpoint = strings.TrimRight(endpoint, "/")
+	}
+	client.OnRetry = logRetry(cmd, client.Retry.MaxAttempts)
+	return client
+}
+
+// newClient creates a library client honouring the global --endpoint flag
+// and logging retries to stderr
+func newClient(cmd *cobra.Command, token string, opts ...hfmodels.ClientOption) *hfmodels.Client {
+	endpoint, _ := cmd.Flags().GetString(endpointFlag)
+	opts = append([]hfmodels.ClientOption{
+		hfmodels.WithEndpoint(endpoint),
+		hfmodels.WithRetryHook(logRetry(cmd, hfmodels.DefaultRetryPolicy().MaxAttempts)),
+	}, opts...)
+	return hfmodels.NewClient(token, opts...)
+}
+
+// fitTerminal sizes and colours the table format for the terminal stdout is,
+// honouring --no-color; output to pipes and files stays plain
+func fitTerminal(cmd *cobra.Command, t utils.Table) utils.Table {
+	out := cmd.OutOrStdout()
+	noColor, _ := cmd.Flags().GetBool(noColorFlag)
+	t.MaxWidth = utils.TerminalWidth(out)
+	t.Color = !noColor && utils.ColorEnabled(out)
+	return t
+}
+
+// logRetry returns a retry hook printing every retry to stderr
+func logRetry(cmd *cobra.Command, maxAttempts int) api.RetryFunc {
+	return func(req *http.Request, attempt int, delay time.Duration, err error) {
+		fmt.Fprintf(cmd.ErrOrStderr(), "Retrying %s in %s (attempt %d/%d): %v\n",
+			req.URL, delay.Round(time.Millisecond), attempt+1, maxAttempts, err)
+	}
+}
+
+// resolveToken returns the token given by flag, falling back to HF_TOKEN
+func resolveToken(token string) string {
+	if token == "" {
+		token = os.Getenv("HF_TOKEN")
+	}
+	return token
+}
+
+// Execute runs the CLI. In-flight requests are cancelled on SIGINT or SIGTERM.
 func Execute() error {
-	return NewRootCmd().Execute()
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	return NewRootCmd().ExecuteContext(ctx)
 }
@@ -0,0 +1,128 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	hfmodels "github.com/Megatherium/hf-go"
+	"github.com/Megatherium/hf-go/internal/pkg/utils"
+	"github.com/spf13/cobra"
+)
+
+// SpaceInfoOptions holds the CLI flags for the space-info command
+type SpaceInfoOptions struct {
+	Revision     string
+	OutputFormat string
+	Token        string
+}
+
+// NewSpaceInfoCmd creates the space-info command
+func NewSpaceInfoCmd() *cobra.Command {
+	opts := &SpaceInfoOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "space-info <space>",
+		Short: "Show the details of a Space",
+		Long: `Show the details of a Space: author, SDK, runtime stage and hardware, the
+models and datasets it uses, likes, tags and the files of the repository
+with their sizes.
+
+Examples:
+  # Details of a Space
+  hf-go space-info gradio/hello_world
+
+  # Details as JSON
+  hf-go space-info --output-format json org/space
+`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runSpaceInfo(cmd, args[0], opts)
+		},
+	}
+
+	// Add flags
+	cmd.Flags().StringVar(&opts.Revision, "revision", "", "Branch, tag or commit hash (default 'main')")
+	cmd.Flags().StringVar(&opts.OutputFormat, "output-format", "table", "Output format: 'table' or 'json'")
+	cmd.Flags().StringVar(&opts.Token, "token", "", "Hugging Face API token (optional, can also use HF_TOKEN env var)")
+
+	return cmd
+}
+
+// runSpaceInfo executes the space-info command
+func runSpaceInfo(cmd *cobra.Command, spaceID string, opts *SpaceInfoOptions) error {
+	if opts.OutputFormat != "table" && opts.OutputFormat != "json" {
+		return fmt.Errorf("unsupported output format: %s (use 'table' or 'json')", opts.OutputFormat)
+	}
+
+	client := newClient(cmd, resolveToken(opts.Token))
+
+	details, err := client.GetSpaceDetailsWithOptions(cmd.Context(), spaceID, hfmodels.SpaceDetailsOptions{
+		Revision: opts.Revision,
+		Blobs:    true,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to get Space details: %w", err)
+	}
+
+	if opts.OutputFormat == "json" {
+		return printJSON(cmd, details)
+	}
+	printSpaceDetails(cmd.OutOrStdout(), details)
+	return nil
+}
+
+// printSpaceDetails prints the details of a Space followed by its files
+func printSpaceDetails(out io.Writer, s *hfmodels.SpaceDetails) {
+	sdk := s.SDK
+	if sdk == "" {
+		sdk = s.CardData.SDK
+	}
+	if sdk != "" && s.CardData.SDKVersion != "" {
+		sdk += " " + s.CardData.SDKVersion
+	}
+
+	rows := [][]string{
+		{"ID", s.ID},
+		{"Author", s.Author},
+		{"Commit", s.SHA},
+	}
+	if s.CardData.Title != "" {
+		rows = append(rows, []string{"Title", strings.TrimSpace(s.CardData.Emoji + " " + s.CardData.Title)})
+	}
+	if s.CardData.ShortDescription != "" {
+		rows = append(rows, []string{"Description", s.CardData.ShortDescription})
+	}
+	rows = append(rows, []string{"SDK", utils.OrNA(sdk)})
+	if s.Runtime != nil {
+		hardware := s.Runtime.Hardware.Current
+		if s.Runtime.Hardware.Requested != "" && s.Runtime.Hardware.Requested != hardware {
+			hardware = strings.TrimSpace(utils.OrNA(hardware) + " (requested " + s.Runtime.Hardware.Requested + ")")
+		}
+		rows = append(rows,
+			[]string{"Stage", utils.OrNA(s.Runtime.Stage)},
+			[]string{"Hardware", utils.OrNA(hardware)},
+		)
+	}
+	if s.Subdomain != "" {
+		rows = append(rows, []string{"URL", "https://" + s.Subdomain + ".hf.space"})
+	}
+	rows = append(rows,
+		[]string{"Models", utils.OrNA(strings.Join(s.Models, ", "))},
+		[]string{"Datasets", utils.OrNA(strings.Join(s.Datasets, ", "))},
+		[]string{"License", utils.OrNA(s.CardData.GetLicense())},
+		[]string{"Likes", utils.CommaNumber(s.Likes)},
+		[]string{"Created", utils.OrNA(utils.FormatDate(s.CreatedAt))},
+		[]string{"Last Modified", utils.OrNA(utils.FormatDate(s.LastModified))},
+		[]string{"Gated", formatGated(s.Gated)},
+		[]string{"Private", strconv.FormatBool(s.Private)},
+	)
+	if s.Disabled {
+		rows = append(rows, []string{"Disabled", "true"})
+	}
+	rows = append(rows, []string{"Tags", utils.OrNA(strings.Join(s.Tags, ", "))})
+	fmt.Fprintln(out, utils.RenderTable([]string{"Field", "Value"}, rows))
+
+	printFiles(out, s.Siblings)
+}
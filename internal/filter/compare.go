@@ -0,0 +1,175 @@
+package filter
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// numberSuffixes are the multipliers accepted after numbers, e.g. 10k or 7b
+var numberSuffixes = map[string]float64{
+	"k": 1e3,
+	"m": 1e6,
+	"b": 1e9,
+	"t": 1e12,
+}
+
+// durationUnits are the units accepted by ParseDuration beyond Go's own
+var durationUnits = map[string]time.Duration{
+	"d":  24 * time.Hour,
+	"w":  7 * 24 * time.Hour,
+	"mo": 30 * 24 * time.Hour,
+	"y":  365 * 24 * time.Hour,
+}
+
+// compare applies op to a field value and a literal, converting the literal
+// to the type of the value. Mismatched types never match.
+func compare(v interface{}, op, lit string) bool {
+	switch v := v.(type) {
+	case string:
+		return compareString(v, op, lit)
+	case []string:
+		// != means no element equals the literal, the others any element
+		if op == OpNe {
+			for _, s := range v {
+				if compareString(s, OpEq, lit) {
+					return false
+				}
+			}
+			return true
+		}
+		for _, s := range v {
+			if compareString(s, op, lit) {
+				return true
+			}
+		}
+		return false
+	case bool:
+		b, err := strconv.ParseBool(lit)
+		if err != nil {
+			return false
+		}
+		return compareOrdered(boolInt(v), boolInt(b), op)
+	case time.Time:
+		t, err := ParseTime(lit)
+		if err != nil || v.IsZero() {
+			return false
+		}
+		return compareOrdered(v.Unix(), t.Unix(), op)
+	}
+
+	n, ok := toFloat(v)
+	if !ok {
+		return false
+	}
+	x, err := ParseNumber(lit)
+	if err != nil {
+		return false
+	}
+	return compareOrdered(n, x, op)
+}
+
+// compareString compares strings case-insensitively
+func compareString(s, op, lit string) bool {
+	s, lit = strings.ToLower(s), strings.ToLower(lit)
+	if op == OpContains {
+		return strings.Contains(s, lit)
+	}
+	return compareOrdered(s, lit, op)
+}
+
+// compareOrdered applies a comparison operator to ordered values
+func compareOrdered[T int | int64 | float64 | string](a, b T, op string) bool {
+	switch op {
+	case OpEq:
+		return a == b
+	case OpNe:
+		return a != b
+	case OpGt:
+		return a > b
+	case OpGe:
+		return a >= b
+	case OpLt:
+		return a < b
+	case OpLe:
+		return a <= b
+	}
+	return false
+}
+
+func boolInt(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+// toFloat converts numeric field values to float64
+func toFloat(v interface{}) (float64, bool) {
+	switch n := v.(type) {
+	case int:
+		return float64(n), true
+	case int64:
+		return float64(n), true
+	case uint64:
+		return float64(n), true
+	case float64:
+		return n, true
+	}
+	return 0, false
+}
+
+// ParseNumber parses a number with an optional k, m, b or t suffix, e.g.
+// "10k", "1.5M" or "7b"
+func ParseNumber(s string) (float64, error) {
+	s = strings.ReplaceAll(strings.TrimSpace(s), "_", "")
+	multiplier := 1.0
+	if len(s) > 1 {
+		if m, ok := numberSuffixes[strings.ToLower(s[len(s)-1:])]; ok {
+			multiplier = m
+			s = s[:len(s)-1]
+		}
+	}
+
+	n, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid number %q", s)
+	}
+	return n * multiplier, nil
+}
+
+// ParseDuration parses a duration such as "90d", "2w", "6mo", "1y" or any
+// duration accepted by time.ParseDuration
+func ParseDuration(s string) (time.Duration, error) {
+	s = strings.TrimSpace(s)
+	i := strings.IndexFunc(s, func(r rune) bool {
+		return (r < '0' || r > '9') && r != '.'
+	})
+	if i > 0 {
+		if unit, ok := durationUnits[strings.ToLower(s[i:])]; ok {
+			n, err := strconv.ParseFloat(s[:i], 64)
+			if err == nil {
+				return time.Duration(n * float64(unit)), nil
+			}
+		}
+	}
+
+	d, err := time.ParseDuration(s)
+	if err != nil {
+		return 0, fmt.Errorf("invalid duration %q (use e.g. 12h, 90d, 2w, 6mo or 1y)", s)
+	}
+	return d, nil
+}
+
+// ParseTime parses a date ("2006-01-02") or an RFC 3339 timestamp
+func ParseTime(s string) (time.Time, error) {
+	if t, err := time.Parse("2006-01-02", s); err == nil {
+		return t, nil
+	}
+	t, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid date %q (use YYYY-MM-DD)", s)
+	}
+	return t, nil
+}
@@ -0,0 +1,279 @@
+// Package filter implements a small expression language evaluated on the
+// client against model fields, e.g.
+//
+//	downloads > 10k and license in (apache-2.0, mit) and lastModified within 90d and gguf
+//
+// Expressions are built by Parse or programmatically with And, Or, Not,
+// Where and Tag, and evaluated against any Record.
+package filter
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Record is a value whose fields an expression can test. Field returns false
+// if the field is unknown or not set.
+//
+// Field values are strings, bools, integers, floats, time.Time or []string.
+type Record interface {
+	Field(name string) (interface{}, bool)
+}
+
+// TagsField is the field bare words are looked up in when they don't name a
+// boolean field
+const TagsField = "tags"
+
+// Expr is a filter expression
+type Expr interface {
+	// Match reports whether r satisfies the expression
+	Match(r Record) bool
+	// Fields returns the fields the expression reads
+	Fields() []string
+	String() string
+}
+
+// Comparison operators
+const (
+	OpEq       = "="
+	OpNe       = "!="
+	OpGt       = ">"
+	OpGe       = ">="
+	OpLt       = "<"
+	OpLe       = "<="
+	OpContains = "~"
+)
+
+// And matches records matching every expression
+func And(exprs ...Expr) Expr {
+	return &logical{op: "and", exprs: exprs}
+}
+
+// Or matches records matching at least one expression
+func Or(exprs ...Expr) Expr {
+	return &logical{op: "or", exprs: exprs}
+}
+
+// Not matches records not matching expr
+func Not(expr Expr) Expr {
+	return &negation{expr: expr}
+}
+
+// Tag matches records having tag, or whose boolean field of that name is true
+func Tag(tag string) Expr {
+	return &bare{word: tag}
+}
+
+// FieldRef builds comparisons on a field
+type FieldRef struct {
+	name string
+}
+
+// Where starts a comparison on a field, e.g. Where("downloads").Gt(10000)
+func Where(field string) FieldRef {
+	return FieldRef{name: field}
+}
+
+// Eq matches records whose field equals v (case-insensitively for strings,
+// any element for lists)
+func (f FieldRef) Eq(v interface{}) Expr { return f.compare(OpEq, v) }
+
+// Ne matches records whose field does not equal v
+func (f FieldRef) Ne(v interface{}) Expr { return f.compare(OpNe, v) }
+
+// Gt matches records whose field is greater than v
+func (f FieldRef) Gt(v interface{}) Expr { return f.compare(OpGt, v) }
+
+// Ge matches records whose field is greater than or equal to v
+func (f FieldRef) Ge(v interface{}) Expr { return f.compare(OpGe, v) }
+
+// Lt matches records whose field is less than v
+func (f FieldRef) Lt(v interface{}) Expr { return f.compare(OpLt, v) }
+
+// Le matches records whose field is less than or equal to v
+func (f FieldRef) Le(v interface{}) Expr { return f.compare(OpLe, v) }
+
+// Contains matches records whose field contains the substring v
+// (case-insensitively; any element for lists)
+func (f FieldRef) Contains(v string) Expr { return f.compare(OpContains, v) }
+
+// In matches records whose field equals any of values
+func (f FieldRef) In(values ...interface{}) Expr {
+	literals := make([]string, len(values))
+	for i, v := range values {
+		literals[i] = literal(v)
+	}
+	return &membership{field: f.name, values: literals}
+}
+
+// Within matches records whose time field is at most d in the past
+func (f FieldRef) Within(d time.Duration) Expr {
+	return &recency{field: f.name, within: d}
+}
+
+func (f FieldRef) compare(op string, v interface{}) Expr {
+	return &comparison{field: f.name, op: op, value: literal(v)}
+}
+
+// literal converts a builder value to the literal form used by parsed expressions
+func literal(v interface{}) string {
+	switch v := v.(type) {
+	case string:
+		return v
+	case time.Time:
+		return v.Format(time.RFC3339)
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case float32:
+		return strconv.FormatFloat(float64(v), 'f', -1, 32)
+	}
+	return fmt.Sprint(v)
+}
+
+// logical is a conjunction or disjunction
+type logical struct {
+	op    string
+	exprs []Expr
+}
+
+func (l *logical) Match(r Record) bool {
+	for _, e := range l.exprs {
+		if e.Match(r) != (l.op == "and") {
+			return l.op != "and"
+		}
+	}
+	return l.op == "and"
+}
+
+func (l *logical) Fields() []string {
+	var fields []string
+	for _, e := range l.exprs {
+		fields = append(fields, e.Fields()...)
+	}
+	return fields
+}
+
+func (l *logical) String() string {
+	parts := make([]string, len(l.exprs))
+	for i, e := range l.exprs {
+		parts[i] = e.String()
+	}
+	return "(" + strings.Join(parts, " "+l.op+" ") + ")"
+}
+
+// negation inverts an expression
+type negation struct {
+	expr Expr
+}
+
+func (n *negation) Match(r Record) bool { return !n.expr.Match(r) }
+func (n *negation) Fields() []string    { return n.expr.Fields() }
+func (n *negation) String() string      { return "not " + n.expr.String() }
+
+// bare is a lone word: a boolean field, or else a tag
+type bare struct {
+	word string
+}
+
+func (b *bare) Match(r Record) bool {
+	if v, ok := r.Field(b.word); ok {
+		if flag, ok := v.(bool); ok {
+			return flag
+		}
+	}
+
+	tags, ok := r.Field(TagsField)
+	if !ok {
+		return false
+	}
+	list, _ := tags.([]string)
+	for _, tag := range list {
+		if strings.EqualFold(tag, b.word) {
+			return true
+		}
+	}
+	return false
+}
+
+func (b *bare) Fields() []string { return []string{TagsField} }
+func (b *bare) String() string   { return quote(b.word) }
+
+// comparison compares a field with a literal
+type comparison struct {
+	field string
+	op    string
+	value string
+}
+
+func (c *comparison) Match(r Record) bool {
+	v, ok := r.Field(c.field)
+	if !ok {
+		return c.op == OpNe
+	}
+	return compare(v, c.op, c.value)
+}
+
+func (c *comparison) Fields() []string { return []string{c.field} }
+func (c *comparison) String() string   { return c.field + " " + c.op + " " + quote(c.value) }
+
+// membership tests a field against a set of literals
+type membership struct {
+	field  string
+	values []string
+}
+
+func (m *membership) Match(r Record) bool {
+	v, ok := r.Field(m.field)
+	if !ok {
+		return false
+	}
+	for _, value := range m.values {
+		if compare(v, OpEq, value) {
+			return true
+		}
+	}
+	return false
+}
+
+func (m *membership) Fields() []string { return []string{m.field} }
+
+func (m *membership) String() string {
+	quoted := make([]string, len(m.values))
+	for i, v := range m.values {
+		quoted[i] = quote(v)
+	}
+	return m.field + " in (" + strings.Join(quoted, ", ") + ")"
+}
+
+// recency tests how long ago a time field is
+type recency struct {
+	field  string
+	within time.Duration
+}
+
+func (w *recency) Match(r Record) bool {
+	v, ok := r.Field(w.field)
+	if !ok {
+		return false
+	}
+	t, ok := v.(time.Time)
+	return ok && !t.IsZero() && time.Since(t) <= w.within
+}
+
+func (w *recency) Fields() []string { return []string{w.field} }
+func (w *recency) String() string   { return w.field + " within " + w.within.String() }
+
+// quote quotes a literal if it would not parse back as a single word
+func quote(s string) string {
+	for _, r := range s {
+		if !isWordRune(r) {
+			return strconv.Quote(s)
+		}
+	}
+	if s == "" || isKeyword(s) {
+		return strconv.Quote(s)
+	}
+	return s
+}
@@ -0,0 +1,298 @@
+package filter
+
+import (
+	"testing"
+	"time"
+)
+
+// record is a map-backed Record
+type record map[string]interface{}
+
+func (r record) Field(name string) (interface{}, bool) {
+	v, ok := r[name]
+	return v, ok
+}
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"gguf", "gguf"},
+		{"downloads > 10k", "downloads > 10k"},
+		{"downloads>=1.5M", "downloads >= 1.5M"},
+		{"author == meta-llama", "author = meta-llama"},
+		{"id contains llama", "id ~ llama"},
+		{"id ~ llama", "id ~ llama"},
+		{"a or b and c", "(a or (b and c))"},
+		{"a and b or c", "((a and b) or c)"},
+		{"(a or b) and c", "((a or b) and c)"},
+		{"not a and b", "(not a and b)"},
+		{"not (a and b)", "not (a and b)"},
+		{"a && !b || c", "((a and not b) or c)"},
+		{"A AND B OR NOT C", "((A and B) or not C)"},
+		{"license in (apache-2.0, mit)", "license in (apache-2.0, mit)"},
+		{`license in ("apache 2.0")`, `license in ("apache 2.0")`},
+		{"license = 'apache 2.0'", `license = "apache 2.0"`},
+		{`author = "and"`, `author = "and"`},
+		{`author = ""`, `author = ""`},
+		{"lastModified within 90d", "lastModified within 2160h0m0s"},
+		{"lastModified within 12h", "lastModified within 12h0m0s"},
+		{"library_name = transformers and tags = license:mit", "(library_name = transformers and tags = license:mit)"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			expr, err := Parse(tt.input)
+			if err != nil {
+				t.Fatalf("Parse(%q) error: %v", tt.input, err)
+			}
+			if got := expr.String(); got != tt.want {
+				t.Errorf("Parse(%q) = %s, want %s", tt.input, got, tt.want)
+			}
+
+			// String() parses back to the same expression
+			again, err := Parse(expr.String())
+			if err != nil {
+				t.Fatalf("Parse(%q) error: %v", expr.String(), err)
+			}
+			if again.String() != expr.String() {
+				t.Errorf("round trip of %q = %s, want %s", tt.input, again.String(), expr.String())
+			}
+		})
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := []string{
+		"",
+		"downloads >",
+		"downloads > > 1",
+		"(a",
+		"a )",
+		"a b",
+		"and",
+		"a and",
+		"not",
+		"license in mit",
+		"license in (mit apache-2.0)",
+		"license in ()",
+		"lastModified within soon",
+		`author = "meta`,
+		"a & b",
+		"a | b",
+		"a $ b",
+	}
+
+	for _, input := range tests {
+		t.Run(input, func(t *testing.T) {
+			if expr, err := Parse(input); err == nil {
+				t.Errorf("Parse(%q) = %s, want an error", input, expr)
+			}
+		})
+	}
+}
+
+func TestMatch(t *testing.T) {
+	r := record{
+		"id":           "meta-llama/Llama-3.1-8B",
+		"downloads":    12000,
+		"likes":        int64(5),
+		"score":        1.5,
+		"license":      "MIT",
+		"tags":         []string{"gguf", "text-generation", "license:mit"},
+		"private":      false,
+		"gated":        true,
+		"lastModified": time.Now().Add(-24 * time.Hour),
+		"createdAt":    time.Time{},
+	}
+
+	tests := []struct {
+		input string
+		want  bool
+	}{
+		// Numbers and suffixes
+		{"downloads > 10k", true},
+		{"downloads >= 12k", true},
+		{"downloads < 12_000", false},
+		{"downloads = 1.2e4", true},
+		{"likes <= 5", true},
+		{"score > 1", true},
+		{"downloads = many", false},
+
+		// Strings, case-insensitive
+		{"license = mit", true},
+		{"license != mit", false},
+		{"license ~ I", true},
+		{"id contains LLAMA", true},
+		{"license in (apache-2.0, mit)", true},
+		{"license in (apache-2.0, gpl-3.0)", false},
+
+		// Lists: any element, none for !=
+		{"tags = gguf", true},
+		{"tags = onnx", false},
+		{"tags != gguf", false},
+		{"tags != onnx", true},
+		{"tags ~ text", true},
+		{"tags in (onnx, gguf)", true},
+
+		// Bare words: boolean fields, else tags
+		{"gguf", true},
+		{"GGUF", true},
+		{"license:mit", true},
+		{"safetensors", false},
+		{"gated", true},
+		{"private", false},
+		{"not private", true},
+		{"gated = true", true},
+		{"private = yes", false},
+
+		// Times
+		{"lastModified within 7d", true},
+		{"lastModified within 1h", false},
+		{"lastModified > 2000-01-01", true},
+		{"lastModified < 2000-01-01T00:00:00Z", false},
+		{"createdAt within 1y", false},
+		{"createdAt > 2000-01-01", false},
+
+		// Unknown fields match != only
+		{"missing = x", false},
+		{"missing != x", true},
+		{"missing > 1", false},
+		{"missing in (x)", false},
+		{"missing within 1d", false},
+
+		// Precedence and grouping
+		{"safetensors or gguf and not private", true},
+		{"(safetensors or gguf) and private", false},
+		{"safetensors or gguf and private", false},
+		{"not (gguf and gated)", false},
+		{"not not gguf", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			expr, err := Parse(tt.input)
+			if err != nil {
+				t.Fatalf("Parse(%q) error: %v", tt.input, err)
+			}
+			if got := expr.Match(r); got != tt.want {
+				t.Errorf("%q matched %v, want %v", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBuilders(t *testing.T) {
+	tests := []struct {
+		expr Expr
+		want string
+	}{
+		{Where("downloads").Gt(10000), "downloads > 10000"},
+		{Where("score").Ge(0.5), "score >= 0.5"},
+		{Where("author").Eq("and"), `author = "and"`},
+		{Where("license").Ne("apache 2.0"), `license != "apache 2.0"`},
+		{Where("id").Contains("llama"), "id ~ llama"},
+		{Where("license").In("mit", "apache-2.0"), "license in (mit, apache-2.0)"},
+		{Where("lastModified").Within(48 * time.Hour), "lastModified within 48h0m0s"},
+		{And(Tag("gguf"), Or(Tag("a"), Not(Tag("b")))), "(gguf and (a or not b))"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			if got := tt.expr.String(); got != tt.want {
+				t.Fatalf("String() = %s, want %s", got, tt.want)
+			}
+			if _, err := Parse(tt.expr.String()); err != nil {
+				t.Errorf("Parse(%q) error: %v", tt.expr.String(), err)
+			}
+		})
+	}
+}
+
+func TestFields(t *testing.T) {
+	expr, err := Parse("downloads > 1k and (gguf or license in (mit)) and not lastModified within 1d")
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := expr.Fields()
+	want := []string{"downloads", TagsField, "license", "lastModified"}
+	if len(got) != len(want) {
+		t.Fatalf("Fields() = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("Fields() = %v, want %v", got, want)
+		}
+	}
+}
+
+func TestParseNumber(t *testing.T) {
+	tests := []struct {
+		input string
+		want  float64
+	}{
+		{"10", 10},
+		{"10k", 1e4},
+		{"10K", 1e4},
+		{"1.5M", 1.5e6},
+		{"7b", 7e9},
+		{"2t", 2e12},
+		{"1_000", 1000},
+		{" 42 ", 42},
+		{"-3", -3},
+		{"1e3", 1000},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := ParseNumber(tt.input)
+			if err != nil {
+				t.Fatalf("ParseNumber(%q) error: %v", tt.input, err)
+			}
+			if got != tt.want {
+				t.Errorf("ParseNumber(%q) = %v, want %v", tt.input, got, tt.want)
+			}
+		})
+	}
+
+	for _, input := range []string{"", "k", "abc", "10x", "1.2.3k"} {
+		if got, err := ParseNumber(input); err == nil {
+			t.Errorf("ParseNumber(%q) = %v, want an error", input, got)
+		}
+	}
+}
+
+func TestParseDuration(t *testing.T) {
+	day := 24 * time.Hour
+	tests := []struct {
+		input string
+		want  time.Duration
+	}{
+		{"90d", 90 * day},
+		{"1.5d", 36 * time.Hour},
+		{"2w", 14 * day},
+		{"6mo", 180 * day},
+		{"1y", 365 * day},
+		{"12h", 12 * time.Hour},
+		{"30m", 30 * time.Minute},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := ParseDuration(tt.input)
+			if err != nil {
+				t.Fatalf("ParseDuration(%q) error: %v", tt.input, err)
+			}
+			if got != tt.want {
+				t.Errorf("ParseDuration(%q) = %v, want %v", tt.input, got, tt.want)
+			}
+		})
+	}
+
+	for _, input := range []string{"", "d", "soon", "3 days"} {
+		if got, err := ParseDuration(input); err == nil {
+			t.Errorf("ParseDuration(%q) = %v, want an error", input, got)
+		}
+	}
+}
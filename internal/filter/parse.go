@@ -0,0 +1,313 @@
+package filter
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// Token kinds of the lexer
+const (
+	tokenEOF = iota
+	tokenWord
+	tokenString
+	tokenOp
+	tokenLParen
+	tokenRParen
+	tokenComma
+)
+
+// keywords are the reserved words of the language, matched case-insensitively
+var keywords = map[string]bool{
+	"and":      true,
+	"or":       true,
+	"not":      true,
+	"in":       true,
+	"within":   true,
+	"contains": true,
+}
+
+type token struct {
+	kind int
+	text string
+	pos  int
+}
+
+// Parse parses a filter expression. The grammar, loosest binding first:
+//
+//	expr       = term { ("or" | "||") term }
+//	term       = factor { ("and" | "&&") factor }
+//	factor     = ("not" | "!") factor | "(" expr ")" | predicate
+//	predicate  = field op value            op is = == != > >= < <= ~ contains
+//	           | field "in" "(" value { "," value } ")"
+//	           | field "within" duration   e.g. 90d, 2w, 6mo, 1y, 12h
+//	           | word                      a boolean field, else a tag
+//
+// Keywords are case-insensitive. Values are bare words or quoted strings;
+// numbers accept k, m, b and t suffixes, dates are YYYY-MM-DD.
+func Parse(input string) (Expr, error) {
+	tokens, err := lex(input)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &parser{tokens: tokens}
+	expr, err := p.expr()
+	if err != nil {
+		return nil, err
+	}
+	if t := p.peek(); t.kind != tokenEOF {
+		return nil, p.errorf(t, "unexpected %q", t.text)
+	}
+	return expr, nil
+}
+
+// lex splits input into tokens
+func lex(input string) ([]token, error) {
+	var tokens []token
+	runes := []rune(input)
+
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case r == '(':
+			tokens = append(tokens, token{kind: tokenLParen, text: "(", pos: i})
+			i++
+		case r == ')':
+			tokens = append(tokens, token{kind: tokenRParen, text: ")", pos: i})
+			i++
+		case r == ',':
+			tokens = append(tokens, token{kind: tokenComma, text: ",", pos: i})
+			i++
+		case r == '"' || r == '\'':
+			end := i + 1
+			for end < len(runes) && runes[end] != r {
+				if runes[end] == '\\' {
+					end++
+				}
+				end++
+			}
+			if end >= len(runes) {
+				return nil, fmt.Errorf("unterminated string at position %d", i+1)
+			}
+			text := string(runes[i+1 : end])
+			if r == '"' {
+				unquoted, err := strconv.Unquote(string(runes[i : end+1]))
+				if err != nil {
+					return nil, fmt.Errorf("invalid string at position %d: %w", i+1, err)
+				}
+				text = unquoted
+			}
+			tokens = append(tokens, token{kind: tokenString, text: text, pos: i})
+			i = end + 1
+		case strings.ContainsRune("=!<>~&|", r):
+			op := string(r)
+			if i+1 < len(runes) {
+				switch two := string(runes[i : i+2]); two {
+				case "==", "!=", ">=", "<=", "&&", "||":
+					op = two
+				}
+			}
+			if op == "&" || op == "|" {
+				return nil, fmt.Errorf("unexpected %q at position %d (use && or ||)", op, i+1)
+			}
+			tokens = append(tokens, token{kind: tokenOp, text: op, pos: i})
+			i += len(op)
+			if op == "==" {
+				tokens[len(tokens)-1].text = OpEq
+			}
+		case isWordRune(r):
+			start := i
+			for i < len(runes) && isWordRune(runes[i]) {
+				i++
+			}
+			tokens = append(tokens, token{kind: tokenWord, text: string(runes[start:i]), pos: start})
+		default:
+			return nil, fmt.Errorf("unexpected %q at position %d", r, i+1)
+		}
+	}
+
+	return append(tokens, token{kind: tokenEOF, pos: len(runes)}), nil
+}
+
+// isWordRune reports whether r can appear in a bare word, which covers tags
+// such as "license:apache-2.0" and model IDs such as "org/name"
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r) || strings.ContainsRune("_-.:/+@", r)
+}
+
+// isKeyword reports whether word is reserved
+func isKeyword(word string) bool {
+	return keywords[strings.ToLower(word)]
+}
+
+// parser is a recursive descent parser over the tokens
+type parser struct {
+	tokens []token
+	pos    int
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.pos]
+}
+
+func (p *parser) next() token {
+	t := p.tokens[p.pos]
+	if t.kind != tokenEOF {
+		p.pos++
+	}
+	return t
+}
+
+// keyword consumes the next token if it is one of words
+func (p *parser) keyword(words ...string) bool {
+	t := p.peek()
+	if t.kind != tokenWord && t.kind != tokenOp {
+		return false
+	}
+	for _, w := range words {
+		if strings.EqualFold(t.text, w) {
+			p.pos++
+			return true
+		}
+	}
+	return false
+}
+
+func (p *parser) errorf(t token, format string, args ...interface{}) error {
+	if t.kind == tokenEOF {
+		return fmt.Errorf("%s at end of expression", fmt.Sprintf(format, args...))
+	}
+	return fmt.Errorf("%s at position %d", fmt.Sprintf(format, args...), t.pos+1)
+}
+
+func (p *parser) expr() (Expr, error) {
+	terms := []Expr{}
+	for {
+		term, err := p.term()
+		if err != nil {
+			return nil, err
+		}
+		terms = append(terms, term)
+		if !p.keyword("or", "||") {
+			break
+		}
+	}
+	if len(terms) == 1 {
+		return terms[0], nil
+	}
+	return Or(terms...), nil
+}
+
+func (p *parser) term() (Expr, error) {
+	factors := []Expr{}
+	for {
+		factor, err := p.factor()
+		if err != nil {
+			return nil, err
+		}
+		factors = append(factors, factor)
+		if !p.keyword("and", "&&") {
+			break
+		}
+	}
+	if len(factors) == 1 {
+		return factors[0], nil
+	}
+	return And(factors...), nil
+}
+
+func (p *parser) factor() (Expr, error) {
+	if p.keyword("not", "!") {
+		expr, err := p.factor()
+		if err != nil {
+			return nil, err
+		}
+		return Not(expr), nil
+	}
+
+	t := p.next()
+	switch t.kind {
+	case tokenLParen:
+		expr, err := p.expr()
+		if err != nil {
+			return nil, err
+		}
+		if closing := p.next(); closing.kind != tokenRParen {
+			return nil, p.errorf(closing, "expected )")
+		}
+		return expr, nil
+	case tokenWord, tokenString:
+		if t.kind == tokenWord && isKeyword(t.text) {
+			return nil, p.errorf(t, "unexpected %q", t.text)
+		}
+		return p.predicate(t.text)
+	}
+	return nil, p.errorf(t, "expected a field or tag")
+}
+
+func (p *parser) predicate(field string) (Expr, error) {
+	t := p.peek()
+	switch {
+	case t.kind == tokenOp && t.text != "!" && t.text != "&&" && t.text != "||":
+		p.next()
+		value, err := p.value()
+		if err != nil {
+			return nil, err
+		}
+		return &comparison{field: field, op: t.text, value: value}, nil
+	case p.keyword("contains"):
+		value, err := p.value()
+		if err != nil {
+			return nil, err
+		}
+		return &comparison{field: field, op: OpContains, value: value}, nil
+	case p.keyword("in"):
+		return p.membership(field)
+	case p.keyword("within"):
+		value, err := p.value()
+		if err != nil {
+			return nil, err
+		}
+		d, err := ParseDuration(value)
+		if err != nil {
+			return nil, p.errorf(t, "%v", err)
+		}
+		return &recency{field: field, within: d}, nil
+	}
+	return &bare{word: field}, nil
+}
+
+func (p *parser) membership(field string) (Expr, error) {
+	if t := p.next(); t.kind != tokenLParen {
+		return nil, p.errorf(t, "expected ( after in")
+	}
+
+	m := &membership{field: field}
+	for {
+		value, err := p.value()
+		if err != nil {
+			return nil, err
+		}
+		m.values = append(m.values, value)
+
+		t := p.next()
+		if t.kind == tokenRParen {
+			return m, nil
+		}
+		if t.kind != tokenComma {
+			return nil, p.errorf(t, "expected , or )")
+		}
+	}
+}
+
+func (p *parser) value() (string, error) {
+	t := p.next()
+	if t.kind != tokenWord && t.kind != tokenString {
+		return "", p.errorf(t, "expected a value")
+	}
+	return t.text, nil
+}
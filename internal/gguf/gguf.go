@@ -0,0 +1,354 @@
+// Package gguf reads the header of GGUF model files: the metadata key/values
+// and the tensor info table, without touching the tensor data.
+package gguf
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"os"
+)
+
+// Magic is the first four bytes of every GGUF file
+const Magic = "GGUF"
+
+// DefaultAlignment is the tensor data alignment when general.alignment is not set
+const DefaultAlignment = 32
+
+// Sanity limits protecting against corrupt or malicious headers
+const (
+	maxStringLen   = 64 << 20
+	maxArrayLen    = 1 << 28
+	maxTensorCount = 1 << 24
+	maxKVCount     = 1 << 20
+	maxDimensions  = 8
+)
+
+// ErrInvalidMagic is returned when the data does not start with the GGUF magic
+var ErrInvalidMagic = errors.New("not a GGUF file")
+
+// Header is the parsed header of a GGUF file
+type Header struct {
+	Version     uint32       `json:"version"`
+	BigEndian   bool         `json:"big_endian,omitempty"`
+	Metadata    []KeyValue   `json:"metadata"`
+	Tensors     []TensorInfo `json:"tensors"`
+	Alignment   uint64       `json:"alignment"`
+	DataOffset  int64        `json:"data_offset"`
+	HeaderBytes int64        `json:"-"`
+}
+
+// KeyValue is a metadata entry
+type KeyValue struct {
+	Key   string      `json:"key"`
+	Type  ValueType   `json:"type"`
+	Value interface{} `json:"value"`
+}
+
+// TensorInfo describes a tensor in the tensor info table
+type TensorInfo struct {
+	Name       string   `json:"name"`
+	Dimensions []uint64 `json:"dimensions"`
+	Type       GGMLType `json:"type"`
+	// Offset is relative to the start of the tensor data
+	Offset uint64 `json:"offset"`
+}
+
+// Elements returns the number of elements in the tensor
+func (t TensorInfo) Elements() uint64 {
+	n := uint64(1)
+	for _, d := range t.Dimensions {
+		n *= d
+	}
+	return n
+}
+
+// Get returns the value of a metadata key
+func (h *Header) Get(key string) (interface{}, bool) {
+	for _, kv := range h.Metadata {
+		if kv.Key == key {
+			return kv.Value, true
+		}
+	}
+	return nil, false
+}
+
+// String returns a string metadata value, or "" if missing or not a string
+func (h *Header) String(key string) string {
+	v, _ := h.Get(key)
+	s, _ := v.(string)
+	return s
+}
+
+// Uint returns an integer metadata value, or false if missing or not an integer
+func (h *Header) Uint(key string) (uint64, bool) {
+	v, ok := h.Get(key)
+	if !ok {
+		return 0, false
+	}
+	return ToUint(v)
+}
+
+// ToUint converts a non-negative integer metadata value of any width to uint64
+func ToUint(v interface{}) (uint64, bool) {
+	switch n := v.(type) {
+	case uint8:
+		return uint64(n), true
+	case uint16:
+		return uint64(n), true
+	case uint32:
+		return uint64(n), true
+	case uint64:
+		return n, true
+	case int8:
+		return uint64(n), n >= 0
+	case int16:
+		return uint64(n), n >= 0
+	case int32:
+		return uint64(n), n >= 0
+	case int64:
+		return uint64(n), n >= 0
+	}
+	return 0, false
+}
+
+// Architecture returns general.architecture, e.g. "llama"
+func (h *Header) Architecture() string {
+	return h.String("general.architecture")
+}
+
+// ArchUint returns an architecture-specific integer such as "block_count"
+// (looked up as "<architecture>.block_count")
+func (h *Header) ArchUint(key string) (uint64, bool) {
+	return h.Uint(h.Architecture() + "." + key)
+}
+
+// FileType returns general.file_type, the predominant quantization of the file
+func (h *Header) FileType() (FileType, bool) {
+	n, ok := h.Uint("general.file_type")
+	return FileType(n), ok
+}
+
+// ParameterCount returns the total number of elements over all tensors
+func (h *Header) ParameterCount() uint64 {
+	var n uint64
+	for _, t := range h.Tensors {
+		n += t.Elements()
+	}
+	return n
+}
+
+// ReadFile reads the header of a local GGUF file
+func ReadFile(path string) (*Header, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return Read(bufio.NewReaderSize(f, 1<<20))
+}
+
+// Read parses a GGUF header from r, which must be positioned at the start of
+// the file. Only the header is consumed, not the tensor data.
+func Read(r io.Reader) (*Header, error) {
+	d := &decoder{r: r, order: binary.LittleEndian}
+
+	var magic [4]byte
+	if _, err := io.ReadFull(d, magic[:]); err != nil {
+		return nil, fmt.Errorf("failed to read magic: %w", err)
+	}
+	if string(magic[:]) != Magic {
+		return nil, ErrInvalidMagic
+	}
+
+	h := &Header{Alignment: DefaultAlignment}
+	h.Version = d.uint32()
+	// Big endian files have a byte-swapped version
+	if h.Version&0xFFFF == 0 && h.Version != 0 {
+		d.order = binary.BigEndian
+		h.BigEndian = true
+		h.Version = bswap32(h.Version)
+	}
+	if d.err != nil {
+		return nil, d.err
+	}
+	if h.Version < 1 || h.Version > 3 {
+		return nil, fmt.Errorf("unsupported GGUF version %d", h.Version)
+	}
+	d.v1 = h.Version == 1
+
+	tensorCount := d.count()
+	kvCount := d.count()
+	if d.err != nil {
+		return nil, d.err
+	}
+	if tensorCount > maxTensorCount || kvCount > maxKVCount {
+		return nil, fmt.Errorf("implausible GGUF header: %d tensors, %d metadata entries", tensorCount, kvCount)
+	}
+
+	// The counts come from untrusted input: start small and let append grow
+	h.Metadata = make([]KeyValue, 0, min(kvCount, 1024))
+	for i := uint64(0); i < kvCount; i++ {
+		key := d.string()
+		typ := ValueType(d.uint32())
+		value := d.value(typ)
+		if d.err != nil {
+			return nil, fmt.Errorf("failed to read metadata entry %d (%q): %w", i, key, d.err)
+		}
+		h.Metadata = append(h.Metadata, KeyValue{Key: key, Type: typ, Value: value})
+	}
+
+	h.Tensors = make([]TensorInfo, 0, min(tensorCount, 1024))
+	for i := uint64(0); i < tensorCount; i++ {
+		var t TensorInfo
+		t.Name = d.string()
+		nDims := d.uint32()
+		if d.err == nil && nDims > maxDimensions {
+			return nil, fmt.Errorf("tensor %q has %d dimensions", t.Name, nDims)
+		}
+		t.Dimensions = make([]uint64, nDims)
+		for j := range t.Dimensions {
+			t.Dimensions[j] = d.count()
+		}
+		t.Type = GGMLType(d.uint32())
+		t.Offset = d.uint64()
+		if d.err != nil {
+			return nil, fmt.Errorf("failed to read tensor info %d: %w", i, d.err)
+		}
+		h.Tensors = append(h.Tensors, t)
+	}
+
+	if alignment, ok := h.Uint("general.alignment"); ok && alignment > 0 {
+		h.Alignment = alignment
+	}
+	h.HeaderBytes = d.n
+	h.DataOffset = d.n
+	if rem := d.n % int64(h.Alignment); rem != 0 {
+		h.DataOffset += int64(h.Alignment) - rem
+	}
+
+	return h, nil
+}
+
+// decoder reads GGUF primitives, remembering the first error
+type decoder struct {
+	r     io.Reader
+	order binary.ByteOrder
+	v1    bool
+	n     int64
+	err   error
+	buf   [8]byte
+}
+
+func (d *decoder) Read(p []byte) (int, error) {
+	n, err := d.r.Read(p)
+	d.n += int64(n)
+	return n, err
+}
+
+func (d *decoder) read(n int) []byte {
+	if d.err != nil {
+		return d.buf[:n]
+	}
+	if _, err := io.ReadFull(d, d.buf[:n]); err != nil {
+		if err == io.EOF {
+			err = io.ErrUnexpectedEOF
+		}
+		d.err = err
+	}
+	return d.buf[:n]
+}
+
+func (d *decoder) uint8() uint8   { return d.read(1)[0] }
+func (d *decoder) uint16() uint16 { return d.order.Uint16(d.read(2)) }
+func (d *decoder) uint32() uint32 { return d.order.Uint32(d.read(4)) }
+func (d *decoder) uint64() uint64 { return d.order.Uint64(d.read(8)) }
+
+// count reads a length or count, which is 32 bits wide in GGUF v1
+func (d *decoder) count() uint64 {
+	if d.v1 {
+		return uint64(d.uint32())
+	}
+	return d.uint64()
+}
+
+func (d *decoder) string() string {
+	n := d.count()
+	if d.err != nil {
+		return ""
+	}
+	if n > maxStringLen {
+		d.err = fmt.Errorf("string of %d bytes exceeds limit", n)
+		return ""
+	}
+	b := make([]byte, n)
+	if _, err := io.ReadFull(d, b); err != nil {
+		if err == io.EOF {
+			err = io.ErrUnexpectedEOF
+		}
+		d.err = err
+		return ""
+	}
+	return string(b)
+}
+
+func (d *decoder) value(typ ValueType) interface{} {
+	switch typ {
+	case TypeUint8:
+		return d.uint8()
+	case TypeInt8:
+		return int8(d.uint8())
+	case TypeUint16:
+		return d.uint16()
+	case TypeInt16:
+		return int16(d.uint16())
+	case TypeUint32:
+		return d.uint32()
+	case TypeInt32:
+		return int32(d.uint32())
+	case TypeUint64:
+		return d.uint64()
+	case TypeInt64:
+		return int64(d.uint64())
+	case TypeFloat32:
+		return math.Float32frombits(d.uint32())
+	case TypeFloat64:
+		return math.Float64frombits(d.uint64())
+	case TypeBool:
+		return d.uint8() != 0
+	case TypeString:
+		return d.string()
+	case TypeArray:
+		elemType := ValueType(d.uint32())
+		n := d.count()
+		if d.err != nil {
+			return nil
+		}
+		if n > maxArrayLen {
+			d.err = fmt.Errorf("array of %d elements exceeds limit", n)
+			return nil
+		}
+		if elemType == TypeArray {
+			d.err = errors.New("nested arrays are not supported")
+			return nil
+		}
+		values := make([]interface{}, 0, min(n, 1<<16))
+		for i := uint64(0); i < n && d.err == nil; i++ {
+			values = append(values, d.value(elemType))
+		}
+		return values
+	default:
+		if d.err == nil {
+			d.err = fmt.Errorf("unknown metadata value type %d", typ)
+		}
+		return nil
+	}
+}
+
+func bswap32(v uint32) uint32 {
+	return v>>24 | v>>8&0xFF00 | v<<8&0xFF0000 | v<<24
+}
@@ -0,0 +1,287 @@
+package gguf
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"io"
+	"math"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// encoder writes synthetic GGUF headers
+type encoder struct {
+	buf   bytes.Buffer
+	order binary.AppendByteOrder
+	v1    bool
+}
+
+func newEncoder(version uint32, order binary.AppendByteOrder) *encoder {
+	e := &encoder{order: order, v1: version == 1}
+	e.buf.WriteString(Magic)
+	e.u32(version)
+	return e
+}
+
+func (e *encoder) u8(v uint8)   { e.buf.WriteByte(v) }
+func (e *encoder) u16(v uint16) { e.buf.Write(e.order.AppendUint16(nil, v)) }
+func (e *encoder) u32(v uint32) { e.buf.Write(e.order.AppendUint32(nil, v)) }
+func (e *encoder) u64(v uint64) { e.buf.Write(e.order.AppendUint64(nil, v)) }
+
+func (e *encoder) count(n uint64) {
+	if e.v1 {
+		e.u32(uint32(n))
+		return
+	}
+	e.u64(n)
+}
+
+func (e *encoder) str(s string) {
+	e.count(uint64(len(s)))
+	e.buf.WriteString(s)
+}
+
+func (e *encoder) key(k string, typ ValueType) {
+	e.str(k)
+	e.u32(uint32(typ))
+}
+
+func (e *encoder) tensor(name string, typ GGMLType, offset uint64, dims ...uint64) {
+	e.str(name)
+	e.u32(uint32(len(dims)))
+	for _, d := range dims {
+		e.count(d)
+	}
+	e.u32(uint32(typ))
+	e.u64(offset)
+}
+
+// testHeader encodes a header with metadata of every value type and two tensors
+func testHeader(version uint32, order binary.AppendByteOrder) []byte {
+	e := newEncoder(version, order)
+	e.count(2)  // tensors
+	e.count(14) // metadata entries
+
+	e.key("general.architecture", TypeString)
+	e.str("llama")
+	e.key("general.alignment", TypeUint32)
+	e.u32(64)
+	e.key("llama.block_count", TypeUint32)
+	e.u32(32)
+	e.key("u8", TypeUint8)
+	e.u8(200)
+	e.key("i8", TypeInt8)
+	e.u8(0xFF)
+	e.key("u16", TypeUint16)
+	e.u16(60000)
+	e.key("i16", TypeInt16)
+	e.u16(0xFFFE)
+	e.key("i32", TypeInt32)
+	e.u32(0xFFFFFFFD)
+	e.key("u64", TypeUint64)
+	e.u64(1 << 40)
+	e.key("i64", TypeInt64)
+	e.u64(math.MaxUint64)
+	e.key("f32", TypeFloat32)
+	e.u32(math.Float32bits(1.5))
+	e.key("f64", TypeFloat64)
+	e.u64(math.Float64bits(-2.25))
+	e.key("bool", TypeBool)
+	e.u8(1)
+	e.key("llama.attention.head_count", TypeArray)
+	e.u32(uint32(TypeInt32))
+	e.count(3)
+	e.u32(32)
+	e.u32(0)
+	e.u32(32)
+
+	e.tensor("token_embd.weight", 12, 0, 4096, 32000)
+	e.tensor("output_norm.weight", 0, 4096*32000, 4096)
+
+	return e.buf.Bytes()
+}
+
+func TestRead(t *testing.T) {
+	tests := []struct {
+		name    string
+		version uint32
+		order   binary.AppendByteOrder
+	}{
+		{"v1", 1, binary.LittleEndian},
+		{"v2", 2, binary.LittleEndian},
+		{"v3", 3, binary.LittleEndian},
+		{"v2 big-endian", 2, binary.BigEndian},
+		{"v3 big-endian", 3, binary.BigEndian},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data := testHeader(tt.version, tt.order)
+			headerLen := int64(len(data))
+			// Tensor data follows the header and must not be consumed
+			data = append(data, bytes.Repeat([]byte{0xAB}, 256)...)
+
+			h, err := Read(bytes.NewReader(data))
+			if err != nil {
+				t.Fatalf("Read error: %v", err)
+			}
+
+			if h.Version != tt.version {
+				t.Errorf("Version = %d, want %d", h.Version, tt.version)
+			}
+			if h.BigEndian != (tt.order == binary.BigEndian) {
+				t.Errorf("BigEndian = %v", h.BigEndian)
+			}
+			if h.Architecture() != "llama" {
+				t.Errorf("Architecture() = %q", h.Architecture())
+			}
+			if n, ok := h.ArchUint("block_count"); !ok || n != 32 {
+				t.Errorf("ArchUint(block_count) = %d, %v", n, ok)
+			}
+
+			want := map[string]interface{}{
+				"u8":                         uint8(200),
+				"i8":                         int8(-1),
+				"u16":                        uint16(60000),
+				"i16":                        int16(-2),
+				"i32":                        int32(-3),
+				"u64":                        uint64(1 << 40),
+				"i64":                        int64(-1),
+				"f32":                        float32(1.5),
+				"f64":                        float64(-2.25),
+				"bool":                       true,
+				"llama.attention.head_count": []interface{}{int32(32), int32(0), int32(32)},
+			}
+			for key, value := range want {
+				got, ok := h.Get(key)
+				if !ok || !reflect.DeepEqual(got, value) {
+					t.Errorf("Get(%q) = %#v, want %#v", key, got, value)
+				}
+			}
+			if _, ok := h.Uint("i8"); ok {
+				t.Error("Uint of a negative value should fail")
+			}
+
+			if len(h.Tensors) != 2 {
+				t.Fatalf("got %d tensors, want 2", len(h.Tensors))
+			}
+			embd := h.Tensors[0]
+			if embd.Name != "token_embd.weight" || !reflect.DeepEqual(embd.Dimensions, []uint64{4096, 32000}) || embd.Type != 12 {
+				t.Errorf("tensor 0 = %+v", embd)
+			}
+			if h.Tensors[1].Offset != 4096*32000 {
+				t.Errorf("tensor 1 offset = %d", h.Tensors[1].Offset)
+			}
+			if n := h.ParameterCount(); n != 4096*32000+4096 {
+				t.Errorf("ParameterCount() = %d", n)
+			}
+
+			if h.Alignment != 64 {
+				t.Errorf("Alignment = %d, want 64", h.Alignment)
+			}
+			if h.HeaderBytes != headerLen {
+				t.Errorf("HeaderBytes = %d, want %d", h.HeaderBytes, headerLen)
+			}
+			if h.DataOffset < h.HeaderBytes || h.DataOffset%64 != 0 || h.DataOffset-h.HeaderBytes >= 64 {
+				t.Errorf("DataOffset = %d for %d header bytes", h.DataOffset, h.HeaderBytes)
+			}
+		})
+	}
+}
+
+func TestReadTruncated(t *testing.T) {
+	for _, version := range []uint32{1, 3} {
+		data := testHeader(version, binary.LittleEndian)
+		for n := 0; n < len(data); n++ {
+			if _, err := Read(bytes.NewReader(data[:n])); err == nil {
+				t.Fatalf("v%d: Read of %d of %d bytes succeeded", version, n, len(data))
+			}
+		}
+	}
+}
+
+// errReader fails every read with err
+type errReader struct{ err error }
+
+func (r errReader) Read([]byte) (int, error) { return 0, r.err }
+
+func TestReadError(t *testing.T) {
+	errRemote := errors.New("range request failed")
+	data := testHeader(3, binary.LittleEndian)
+	for n := 0; n < len(data); n++ {
+		_, err := Read(io.MultiReader(bytes.NewReader(data[:n]), errReader{errRemote}))
+		if !errors.Is(err, errRemote) {
+			t.Fatalf("Read failing after %d of %d bytes = %v, want the reader's error", n, len(data), err)
+		}
+	}
+
+	// A short read in the middle of a string is unexpected, not a clean EOF
+	for n := 4; n < len(data); n++ {
+		if _, err := Read(bytes.NewReader(data[:n])); !errors.Is(err, io.ErrUnexpectedEOF) {
+			t.Fatalf("Read of %d of %d bytes = %v, want %v", n, len(data), err, io.ErrUnexpectedEOF)
+		}
+	}
+}
+
+func TestReadInvalid(t *testing.T) {
+	header := func(version uint32, tensors, kvs uint64, body func(e *encoder)) []byte {
+		e := newEncoder(version, binary.LittleEndian)
+		e.count(tensors)
+		e.count(kvs)
+		if body != nil {
+			body(e)
+		}
+		return e.buf.Bytes()
+	}
+
+	tests := []struct {
+		name string
+		data []byte
+		want string
+	}{
+		{"bad magic", []byte("GGML\x03\x00\x00\x00"), "not a GGUF file"},
+		{"version 0", header(0, 0, 0, nil), "unsupported GGUF version"},
+		{"version 4", header(4, 0, 0, nil), "unsupported GGUF version"},
+		{"too many tensors", header(3, maxTensorCount+1, 0, nil), "implausible"},
+		{"too many metadata entries", header(3, 0, maxKVCount+1, nil), "implausible"},
+		{"v1 counts are 32 bits", header(1, 1<<31, 0, nil), "implausible"},
+		{"huge but plausible counts", header(3, maxTensorCount, maxKVCount, nil), "EOF"},
+		{"huge string", header(3, 0, 1, func(e *encoder) {
+			e.count(maxStringLen + 1)
+		}), "exceeds limit"},
+		{"huge array", header(3, 0, 1, func(e *encoder) {
+			e.key("a", TypeArray)
+			e.u32(uint32(TypeUint8))
+			e.count(maxArrayLen + 1)
+		}), "exceeds limit"},
+		{"nested array", header(3, 0, 1, func(e *encoder) {
+			e.key("a", TypeArray)
+			e.u32(uint32(TypeArray))
+			e.count(1)
+		}), "nested arrays"},
+		{"unknown value type", header(3, 0, 1, func(e *encoder) {
+			e.key("a", ValueType(99))
+		}), "unknown metadata value type"},
+		{"too many dimensions", header(3, 1, 0, func(e *encoder) {
+			e.tensor("t", 0, 0, 1, 1, 1, 1, 1, 1, 1, 1, 1)
+		}), "dimensions"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Read(bytes.NewReader(tt.data))
+			if err == nil {
+				t.Fatal("Read succeeded, want an error")
+			}
+			if !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("Read error = %v, want it to contain %q", err, tt.want)
+			}
+		})
+	}
+
+	if _, err := Read(bytes.NewReader([]byte("GGML"))); !errors.Is(err, ErrInvalidMagic) {
+		t.Errorf("Read error = %v, want ErrInvalidMagic", err)
+	}
+}
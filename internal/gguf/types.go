@@ -0,0 +1,155 @@
+package gguf
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// ValueType is the type of a metadata value
+type ValueType uint32
+
+// Metadata value types
+const (
+	TypeUint8   ValueType = 0
+	TypeInt8    ValueType = 1
+	TypeUint16  ValueType = 2
+	TypeInt16   ValueType = 3
+	TypeUint32  ValueType = 4
+	TypeInt32   ValueType = 5
+	TypeFloat32 ValueType = 6
+	TypeBool    ValueType = 7
+	TypeString  ValueType = 8
+	TypeArray   ValueType = 9
+	TypeUint64  ValueType = 10
+	TypeInt64   ValueType = 11
+	TypeFloat64 ValueType = 12
+)
+
+var valueTypeNames = map[ValueType]string{
+	TypeUint8:   "uint8",
+	TypeInt8:    "int8",
+	TypeUint16:  "uint16",
+	TypeInt16:   "int16",
+	TypeUint32:  "uint32",
+	TypeInt32:   "int32",
+	TypeFloat32: "float32",
+	TypeBool:    "bool",
+	TypeString:  "string",
+	TypeArray:   "array",
+	TypeUint64:  "uint64",
+	TypeInt64:   "int64",
+	TypeFloat64: "float64",
+}
+
+func (t ValueType) String() string {
+	if name, ok := valueTypeNames[t]; ok {
+		return name
+	}
+	return fmt.Sprintf("type(%d)", uint32(t))
+}
+
+// MarshalJSON encodes the type by name
+func (t ValueType) MarshalJSON() ([]byte, error) {
+	return json.Marshal(t.String())
+}
+
+// GGMLType is the storage type of a tensor
+type GGMLType uint32
+
+var ggmlTypeNames = map[GGMLType]string{
+	0:  "F32",
+	1:  "F16",
+	2:  "Q4_0",
+	3:  "Q4_1",
+	6:  "Q5_0",
+	7:  "Q5_1",
+	8:  "Q8_0",
+	9:  "Q8_1",
+	10: "Q2_K",
+	11: "Q3_K",
+	12: "Q4_K",
+	13: "Q5_K",
+	14: "Q6_K",
+	15: "Q8_K",
+	16: "IQ2_XXS",
+	17: "IQ2_XS",
+	18: "IQ3_XXS",
+	19: "IQ1_S",
+	20: "IQ4_NL",
+	21: "IQ3_S",
+	22: "IQ2_S",
+	23: "IQ4_XS",
+	24: "I8",
+	25: "I16",
+	26: "I32",
+	27: "I64",
+	28: "F64",
+	29: "IQ1_M",
+	30: "BF16",
+	34: "TQ1_0",
+	35: "TQ2_0",
+	39: "MXFP4",
+}
+
+func (t GGMLType) String() string {
+	if name, ok := ggmlTypeNames[t]; ok {
+		return name
+	}
+	return fmt.Sprintf("GGML_TYPE_%d", uint32(t))
+}
+
+// MarshalJSON encodes the type by name
+func (t GGMLType) MarshalJSON() ([]byte, error) {
+	return json.Marshal(t.String())
+}
+
+// FileType is the value of general.file_type (llama.cpp's llama_ftype)
+type FileType uint32
+
+var fileTypeNames = map[FileType]string{
+	0:  "F32",
+	1:  "F16",
+	2:  "Q4_0",
+	3:  "Q4_1",
+	7:  "Q8_0",
+	8:  "Q5_0",
+	9:  "Q5_1",
+	10: "Q2_K",
+	11: "Q3_K_S",
+	12: "Q3_K_M",
+	13: "Q3_K_L",
+	14: "Q4_K_S",
+	15: "Q4_K_M",
+	16: "Q5_K_S",
+	17: "Q5_K_M",
+	18: "Q6_K",
+	19: "IQ2_XXS",
+	20: "IQ2_XS",
+	21: "Q2_K_S",
+	22: "IQ3_XS",
+	23: "IQ3_XXS",
+	24: "IQ1_S",
+	25: "IQ4_NL",
+	26: "IQ3_S",
+	27: "IQ3_M",
+	28: "IQ2_S",
+	29: "IQ2_M",
+	30: "IQ4_XS",
+	31: "IQ1_M",
+	32: "BF16",
+	36: "TQ1_0",
+	37: "TQ2_0",
+	38: "MXFP4_MOE",
+}
+
+func (t FileType) String() string {
+	if name, ok := fileTypeNames[t]; ok {
+		return name
+	}
+	return fmt.Sprintf("FTYPE_%d", uint32(t))
+}
+
+// MarshalJSON encodes the file type by name
+func (t FileType) MarshalJSON() ([]byte, error) {
+	return json.Marshal(t.String())
+}
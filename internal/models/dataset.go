@@ -0,0 +1,175 @@
+package models
+
+import (
+	"strings"
+	"time"
+)
+
+// Dataset represents a Hugging Face dataset in a listing
+type Dataset struct {
+	ID            string    `json:"id"`
+	Author        string    `json:"author"`
+	Downloads     int       `json:"downloads"`
+	Likes         int       `json:"likes"`
+	LastModified  time.Time `json:"lastModified"`
+	CreatedAt     time.Time `json:"createdAt,omitzero"`
+	Private       bool      `json:"private"`
+	Gated         bool      `json:"gated,omitempty"`
+	TrendingScore float64   `json:"trending_score,omitempty"`
+	Tags          []string  `json:"tags,omitempty"`
+	Description   string    `json:"description,omitempty"`
+	SHA           string    `json:"sha,omitempty"`
+}
+
+// TaskCategories returns the task categories of the dataset, from its tags
+func (d Dataset) TaskCategories() []string {
+	return tagValues(d.Tags, "task_categories:")
+}
+
+// SizeCategories returns the size categories of the dataset, e.g.
+// "10K<n<100K", from its tags
+func (d Dataset) SizeCategories() []string {
+	return tagValues(d.Tags, "size_categories:")
+}
+
+// Languages returns the languages of the dataset, from its tags
+func (d Dataset) Languages() []string {
+	return tagValues(d.Tags, "language:")
+}
+
+// License returns the license of the dataset, from its tags
+func (d Dataset) License() string {
+	if licenses := tagValues(d.Tags, "license:"); len(licenses) > 0 {
+		return licenses[0]
+	}
+	return ""
+}
+
+// tagValues returns the values of the tags with the given prefix, e.g. the
+// languages of the "language:en" tags
+func tagValues(tags []string, prefix string) []string {
+	var values []string
+	for _, tag := range tags {
+		if value, ok := strings.CutPrefix(tag, prefix); ok {
+			values = append(values, value)
+		}
+	}
+	return values
+}
+
+// ListDatasetsOptions contains parameters for filtering and sorting datasets.
+// The list filters take several values, which datasets must all have.
+type ListDatasetsOptions struct {
+	Search string
+	Author string
+	// Filters are tags as the Hub's filter parameter takes them, e.g.
+	// "modality:text"
+	Filters []string
+	Tags    []string
+	// TaskCategories, e.g. "text-classification"
+	TaskCategories []string
+	// SizeCategories, e.g. "1K<n<10K"
+	SizeCategories []string
+	// Languages, e.g. "en"
+	Languages []string
+	Limit     int
+	Sort      string
+	Direction int
+	Token     string
+	// Full requests every property, such as the description and commit
+	Full bool
+	// Match, if set, filters datasets on the client after the server-side
+	// filters above; Limit then counts matching datasets only
+	Match func(Dataset) bool
+}
+
+// ServerFilters returns the values to send as filter= parameters, with the
+// category and language prefixes the Hub's dataset tags use
+func (o ListDatasetsOptions) ServerFilters() []string {
+	filters := append([]string{}, o.Filters...)
+	filters = append(filters, o.Tags...)
+	for _, prefixed := range []struct {
+		prefix string
+		values []string
+	}{
+		{"task_categories:", o.TaskCategories},
+		{"size_categories:", o.SizeCategories},
+		{"language:", o.Languages},
+	} {
+		for _, v := range prefixed.values {
+			filters = append(filters, prefixed.prefix+v)
+		}
+	}
+	return filters
+}
+
+// DatasetDetails contains detailed dataset information including files
+type DatasetDetails struct {
+	ID           string          `json:"id"`
+	SHA          string          `json:"sha"`
+	Author       string          `json:"author"`
+	Downloads    int             `json:"downloads"`
+	Likes        int             `json:"likes"`
+	CreatedAt    time.Time       `json:"createdAt"`
+	LastModified time.Time       `json:"lastModified"`
+	Private      bool            `json:"private"`
+	Gated        Gated           `json:"gated"`
+	Disabled     bool            `json:"disabled"`
+	Tags         []string        `json:"tags"`
+	Description  string          `json:"description,omitempty"`
+	Citation     string          `json:"citation,omitempty"`
+	Siblings     []Sibling       `json:"siblings"`
+	CardData     DatasetCardData `json:"cardData"`
+}
+
+// DatasetCardData contains dataset card metadata. The list fields can be a
+// string or a list of strings in the card; use the methods to read them.
+type DatasetCardData struct {
+	PrettyName     string      `json:"pretty_name,omitempty"`
+	License        interface{} `json:"license,omitempty"`
+	Language       interface{} `json:"language,omitempty"`
+	TaskCategories interface{} `json:"task_categories,omitempty"`
+	SizeCategories interface{} `json:"size_categories,omitempty"`
+}
+
+// GetLicense returns the license (the first one if several)
+func (c DatasetCardData) GetLicense() string {
+	if licenses := stringList(c.License); len(licenses) > 0 {
+		return licenses[0]
+	}
+	return ""
+}
+
+// GetLanguages returns the languages of the dataset
+func (c DatasetCardData) GetLanguages() []string {
+	return stringList(c.Language)
+}
+
+// GetTaskCategories returns the task categories of the dataset
+func (c DatasetCardData) GetTaskCategories() []string {
+	return stringList(c.TaskCategories)
+}
+
+// GetSizeCategories returns the size categories of the dataset
+func (c DatasetCardData) GetSizeCategories() []string {
+	return stringList(c.SizeCategories)
+}
+
+// stringList converts a card value that is a string or a list to a list
+func stringList(v interface{}) []string {
+	switch v := v.(type) {
+	case string:
+		if v != "" {
+			return []string{v}
+		}
+	case []interface{}:
+		var values []string
+		for _, item := range v {
+			if s, ok := item.(string); ok {
+				values = append(values, s)
+			}
+		}
+		return values
+	}
+	return nil
+}
@@ -0,0 +1,100 @@
+package models
+
+import (
+	"encoding/json"
+	"slices"
+	"testing"
+)
+
+func TestServerFilters(t *testing.T) {
+	tests := []struct {
+		name string
+		opts ListDatasetsOptions
+		want []string
+	}{
+		{"none", ListDatasetsOptions{}, []string{}},
+		{"filters and tags unchanged", ListDatasetsOptions{
+			Filters: []string{"modality:text"},
+			Tags:    []string{"arxiv:2306.01116"},
+		}, []string{"modality:text", "arxiv:2306.01116"}},
+		{"prefixed", ListDatasetsOptions{
+			TaskCategories: []string{"text-classification", "translation"},
+			SizeCategories: []string{"1K<n<10K"},
+			Languages:      []string{"en", "fr"},
+		}, []string{
+			"task_categories:text-classification", "task_categories:translation",
+			"size_categories:1K<n<10K", "language:en", "language:fr",
+		}},
+		{"all", ListDatasetsOptions{
+			Filters:   []string{"format:parquet"},
+			Tags:      []string{"synthetic"},
+			Languages: []string{"de"},
+		}, []string{"format:parquet", "synthetic", "language:de"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.opts.ServerFilters(); !slices.Equal(got, tt.want) {
+				t.Errorf("ServerFilters() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	// The options' own slices must not be modified
+	filters := make([]string, 1, 4)
+	filters[0] = "modality:text"
+	opts := ListDatasetsOptions{Filters: filters, Languages: []string{"en"}}
+	opts.ServerFilters()
+	if got := filters[:2]; got[1] != "" {
+		t.Errorf("ServerFilters appended to Filters: %q", got)
+	}
+}
+
+func TestStringList(t *testing.T) {
+	tests := []struct {
+		card string
+		want []string
+	}{
+		{`"mit"`, []string{"mit"}},
+		{`""`, nil},
+		{`["en", "fr"]`, []string{"en", "fr"}},
+		{`["en", 1, null, "de"]`, []string{"en", "de"}},
+		{`[]`, nil},
+		{`null`, nil},
+		{`42`, nil},
+		{`{"name": "mit"}`, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.card, func(t *testing.T) {
+			var v interface{}
+			if err := json.Unmarshal([]byte(tt.card), &v); err != nil {
+				t.Fatal(err)
+			}
+			if got := stringList(v); !slices.Equal(got, tt.want) {
+				t.Errorf("stringList(%s) = %q, want %q", tt.card, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDatasetCardData(t *testing.T) {
+	var card DatasetCardData
+	data := `{"license": ["apache-2.0", "cc-by-4.0"], "language": "en", "task_categories": ["text-generation"]}`
+	if err := json.Unmarshal([]byte(data), &card); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := card.GetLicense(); got != "apache-2.0" {
+		t.Errorf("GetLicense() = %q, want apache-2.0", got)
+	}
+	if got := card.GetLanguages(); !slices.Equal(got, []string{"en"}) {
+		t.Errorf("GetLanguages() = %q, want [en]", got)
+	}
+	if got := card.GetTaskCategories(); !slices.Equal(got, []string{"text-generation"}) {
+		t.Errorf("GetTaskCategories() = %q, want [text-generation]", got)
+	}
+	if got := card.GetSizeCategories(); got != nil {
+		t.Errorf("GetSizeCategories() = %q, want none", got)
+	}
+}
@@ -0,0 +1,137 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// ModelDetails contains detailed model information including files
+type ModelDetails struct {
+	ID           string           `json:"id"`
+	SHA          string           `json:"sha"`
+	Author       string           `json:"author"`
+	Downloads    int              `json:"downloads"`
+	Likes        int              `json:"likes"`
+	CreatedAt    time.Time        `json:"createdAt"`
+	LastModified time.Time        `json:"lastModified"`
+	Private      bool             `json:"private"`
+	Gated        Gated            `json:"gated"`
+	PipelineTag  string           `json:"pipeline_tag"`
+	LibraryName  string           `json:"library_name"`
+	Tags         []string         `json:"tags"`
+	Siblings     []Sibling        `json:"siblings"`
+	CardData     CardData         `json:"cardData"`
+	GGUFInfo     *GGUFInfo        `json:"gguf"`
+	Safetensors  *SafetensorsInfo `json:"safetensors,omitempty"`
+}
+
+// Gated is the access control of a repository: false, or the approval mode
+// ("auto" or "manual") of a gated repository
+type Gated string
+
+// UnmarshalJSON accepts both the boolean and the string form of "gated"
+func (g *Gated) UnmarshalJSON(data []byte) error {
+	var v interface{}
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+
+	switch v := v.(type) {
+	case bool:
+		*g = ""
+		if v {
+			*g = "true"
+		}
+	case string:
+		*g = Gated(v)
+		if v == "false" {
+			*g = ""
+		}
+	default:
+		*g = ""
+	}
+	return nil
+}
+
+// MarshalJSON encodes an ungated repository as false, like the Hub does
+func (g Gated) MarshalJSON() ([]byte, error) {
+	if g == "" {
+		return []byte("false"), nil
+	}
+	return json.Marshal(string(g))
+}
+
+// IsGated reports whether users must request access to the repository
+func (g Gated) IsGated() bool {
+	return g != ""
+}
+
+// Sibling represents a file in the model repository. Size, BlobID and LFS
+// are only set when the details were requested with blob information.
+type Sibling struct {
+	RFilename string      `json:"rfilename"`
+	Size      int64       `json:"size,omitempty"`
+	BlobID    string      `json:"blobId,omitempty"`
+	LFS       *SiblingLFS `json:"lfs,omitempty"`
+}
+
+// SiblingLFS describes a sibling stored with Git LFS
+type SiblingLFS struct {
+	SHA256      string `json:"sha256"`
+	Size        int64  `json:"size"`
+	PointerSize int64  `json:"pointerSize"`
+}
+
+// CardData contains model card metadata
+type CardData struct {
+	ModelName   string      `json:"model_name"`
+	ModelType   string      `json:"model_type"`
+	BaseModel   interface{} `json:"base_model"` // Can be string or []string
+	License     interface{} `json:"license"`    // Can be string or []string
+	QuantizedBy string      `json:"quantized_by"`
+}
+
+// GetBaseModel returns base_model as a string (first one if array)
+func (c CardData) GetBaseModel() string {
+	switch v := c.BaseModel.(type) {
+	case string:
+		return v
+	case []interface{}:
+		if len(v) > 0 {
+			if s, ok := v[0].(string); ok {
+				return s
+			}
+		}
+	}
+	return ""
+}
+
+// GetLicense returns license as a string (first one if array)
+func (c CardData) GetLicense() string {
+	switch v := c.License.(type) {
+	case string:
+		return v
+	case []interface{}:
+		if len(v) > 0 {
+			if s, ok := v[0].(string); ok {
+				return s
+			}
+		}
+	}
+	return ""
+}
+
+// GGUFInfo contains GGUF-specific model information
+type GGUFInfo struct {
+	Total         int64  `json:"total"`
+	Architecture  string `json:"architecture"`
+	ContextLength int    `json:"context_length"`
+}
+
+// SafetensorsInfo is the parameter count of the safetensors weights as
+// computed by the Hub
+type SafetensorsInfo struct {
+	// Parameters maps dtypes to their number of parameters
+	Parameters map[string]int64 `json:"parameters"`
+	Total      int64            `json:"total"`
+}
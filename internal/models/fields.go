@@ -0,0 +1,164 @@
+package models
+
+import (
+	"slices"
+	"strings"
+	"time"
+)
+
+// FieldNames are the fields Model.Field and ModelDetails.Field know, as used
+// by filter expressions. Names are matched case-insensitively and ignoring
+// "_" and "-", so "lastModified", "last_modified" and "lastmodified" are the
+// same field.
+var FieldNames = []string{
+	"id", "author", "downloads", "downloadsAllTime", "likes", "lastModified", "createdAt",
+	"library", "task", "private", "gated", "trendingScore", "tags", "license",
+	"parameters", "sha", "baseModel",
+}
+
+// fieldAliases maps alternative names to the canonical ones
+var fieldAliases = map[string]string{
+	"modified":    "lastmodified",
+	"created":     "createdat",
+	"libraryname": "library",
+	"pipelinetag": "task",
+	"trending":    "trendingscore",
+	"params":      "parameters",
+	"tag":         "tags",
+}
+
+// fieldExpand maps fields to the expand[] properties a model listing must
+// request for them to be set
+var fieldExpand = map[string][]string{
+	"license":          {ExpandCardData},
+	"basemodel":        {ExpandCardData},
+	"parameters":       {ExpandSafetensors, ExpandGGUF},
+	"sha":              {ExpandSHA},
+	"downloadsalltime": {ExpandDownloadsAllTime},
+}
+
+// CanonicalField returns the canonical lowercase name of a field, and false
+// if the field is unknown
+func CanonicalField(name string) (string, bool) {
+	key := strings.ToLower(strings.NewReplacer("_", "", "-", "").Replace(name))
+	if alias, ok := fieldAliases[key]; ok {
+		key = alias
+	}
+	for _, f := range FieldNames {
+		if strings.ToLower(f) == key {
+			return key, true
+		}
+	}
+	return key, false
+}
+
+// FieldExpand returns the expand[] properties ListModelsOptions must request
+// for the given fields to be set on listed models
+func FieldExpand(fields ...string) []string {
+	var expand []string
+	for _, name := range fields {
+		key, _ := CanonicalField(name)
+		for _, e := range fieldExpand[key] {
+			if !slices.Contains(expand, e) {
+				expand = append(expand, e)
+			}
+		}
+	}
+	return expand
+}
+
+// Field returns the value of a field by name (see FieldNames), and false if
+// the field is unknown or not set on m
+func (m Model) Field(name string) (interface{}, bool) {
+	key, _ := CanonicalField(name)
+	switch key {
+	case "downloadsalltime":
+		return m.DownloadsAllTime, m.DownloadsAllTime > 0
+	case "trendingscore":
+		return m.TrendingScore, true
+	case "gated":
+		return m.Gated, true
+	case "license":
+		return nonEmpty(m.License())
+	case "parameters":
+		return m.ParameterCount(), m.ParameterCount() > 0
+	case "basemodel":
+		if m.CardData == nil {
+			return nil, false
+		}
+		return nonEmpty(m.CardData.GetBaseModel())
+	}
+	return commonField(key, repoFields{
+		id: m.ID, author: m.Author, downloads: m.Downloads, likes: m.Likes,
+		lastModified: m.LastModified, createdAt: m.CreatedAt, library: m.LibraryName,
+		task: m.PipelineTag, private: m.Private, tags: m.Tags, sha: m.SHA,
+	})
+}
+
+// Field returns the value of a field by name (see FieldNames), and false if
+// the field is unknown or not set on d
+func (d ModelDetails) Field(name string) (interface{}, bool) {
+	key, _ := CanonicalField(name)
+	switch key {
+	case "gated":
+		return d.Gated.IsGated(), true
+	case "license":
+		return nonEmpty(d.CardData.GetLicense())
+	case "parameters":
+		var total int64
+		if d.Safetensors != nil && d.Safetensors.Total > 0 {
+			total = d.Safetensors.Total
+		} else if d.GGUFInfo != nil {
+			total = d.GGUFInfo.Total
+		}
+		return total, total > 0
+	case "basemodel":
+		return nonEmpty(d.CardData.GetBaseModel())
+	}
+	return commonField(key, repoFields{
+		id: d.ID, author: d.Author, downloads: d.Downloads, likes: d.Likes,
+		lastModified: d.LastModified, createdAt: d.CreatedAt, library: d.LibraryName,
+		task: d.PipelineTag, private: d.Private, tags: d.Tags, sha: d.SHA,
+	})
+}
+
+// repoFields are the fields Model and ModelDetails have in common
+type repoFields struct {
+	id, author, library, task, sha string
+	downloads, likes               int
+	lastModified, createdAt        time.Time
+	private                        bool
+	tags                           []string
+}
+
+func commonField(key string, f repoFields) (interface{}, bool) {
+	switch key {
+	case "id":
+		return f.id, true
+	case "author":
+		return nonEmpty(f.author)
+	case "downloads":
+		return f.downloads, true
+	case "likes":
+		return f.likes, true
+	case "lastmodified":
+		return f.lastModified, !f.lastModified.IsZero()
+	case "createdat":
+		return f.createdAt, !f.createdAt.IsZero()
+	case "library":
+		return nonEmpty(f.library)
+	case "task":
+		return nonEmpty(f.task)
+	case "private":
+		return f.private, true
+	case "tags":
+		return f.tags, true
+	case "sha":
+		return nonEmpty(f.sha)
+	}
+	return nil, false
+}
+
+func nonEmpty(s string) (interface{}, bool) {
+	return s, s != ""
+}
@@ -1,8 +1,14 @@
 package models
 
-import "time"
+import (
+	"slices"
+	"strings"
+	"time"
+)
 
-// Model represents a Hugging Face model with its metadata
+// Model represents a Hugging Face model with its metadata. The fields after
+// TrendingScore are only set when requested with ListModelsOptions.Expand or
+// Full, except Tags and CreatedAt which the Hub always returns.
 type Model struct {
 	ID            string    `json:"id"`
 	Author        string    `json:"author"`
@@ -14,8 +20,81 @@ type Model struct {
 	Private       bool      `json:"private"`
 	Gated         bool      `json:"gated,omitempty"`
 	TrendingScore float64   `json:"trending_score,omitempty"`
+
+	Tags             []string         `json:"tags,omitempty"`
+	CreatedAt        time.Time        `json:"createdAt,omitzero"`
+	SHA              string           `json:"sha,omitempty"`
+	DownloadsAllTime int              `json:"downloadsAllTime,omitempty"`
+	Siblings         []Sibling        `json:"siblings,omitempty"`
+	CardData         *CardData        `json:"cardData,omitempty"`
+	Safetensors      *SafetensorsInfo `json:"safetensors,omitempty"`
+	GGUF             *GGUFInfo        `json:"gguf,omitempty"`
+}
+
+// License returns the license of the model card, falling back to the
+// "license:" tag
+func (m Model) License() string {
+	if m.CardData != nil {
+		if license := m.CardData.GetLicense(); license != "" {
+			return license
+		}
+	}
+	for _, tag := range m.Tags {
+		if license, ok := strings.CutPrefix(tag, "license:"); ok {
+			return license
+		}
+	}
+	return ""
 }
 
+// ParameterCount returns the number of parameters reported for the
+// safetensors or GGUF weights, or 0 if unknown
+func (m Model) ParameterCount() int64 {
+	if m.Safetensors != nil && m.Safetensors.Total > 0 {
+		return m.Safetensors.Total
+	}
+	if m.GGUF != nil {
+		return m.GGUF.Total
+	}
+	return 0
+}
+
+// Expandable model properties of the Hub listing, requested with the
+// expand[] query parameter
+const (
+	ExpandAuthor           = "author"
+	ExpandCardData         = "cardData"
+	ExpandCreatedAt        = "createdAt"
+	ExpandDownloads        = "downloads"
+	ExpandDownloadsAllTime = "downloadsAllTime"
+	ExpandGated            = "gated"
+	ExpandGGUF             = "gguf"
+	ExpandLastModified     = "lastModified"
+	ExpandLibraryName      = "library_name"
+	ExpandLikes            = "likes"
+	ExpandPipelineTag      = "pipeline_tag"
+	ExpandPrivate          = "private"
+	ExpandSafetensors      = "safetensors"
+	ExpandSHA              = "sha"
+	ExpandSiblings         = "siblings"
+	ExpandTags             = "tags"
+	ExpandTrendingScore    = "trendingScore"
+)
+
+// BaseExpand are the properties decoded into every Model. With expand[] the
+// Hub only returns the listed properties, so these are always requested
+// along with ListModelsOptions.Expand.
+var BaseExpand = []string{
+	ExpandAuthor, ExpandDownloads, ExpandLikes, ExpandLastModified, ExpandLibraryName,
+	ExpandPipelineTag, ExpandPrivate, ExpandGated, ExpandTrendingScore, ExpandTags, ExpandCreatedAt,
+}
+
+// FullExpand are all the properties decoded into Model, requested by
+// ListModelsOptions.Full
+var FullExpand = append(append([]string{}, BaseExpand...),
+	ExpandSHA, ExpandDownloadsAllTime, ExpandSiblings, ExpandCardData, ExpandSafetensors, ExpandGGUF,
+)
+
 // ListModelsOptions contains parameters for filtering and sorting models
 type ListModelsOptions struct {
 	Search      string
@@ -25,8 +104,102 @@ type ListModelsOptions struct {
 	LibraryName string
 	Language    string
 	Tag         string
-	Limit       int
-	Sort        string
-	Direction   int
-	Token       string
+	// Filters, Tags, Languages, Libraries and PipelineTags take several
+	// values, which models must all have (sent as repeated filter=
+	// parameters), or any of them with MatchAny
+	Filters      []string
+	Tags         []string
+	Languages    []string
+	Libraries    []string
+	PipelineTags []string
+	// MatchAny makes the values of each of the lists above alternatives,
+	// checked on the client; the lists still all have to match
+	MatchAny  bool
+	Limit     int
+	Sort      string
+	Direction int
+	Token     string
+	// Expand requests additional properties, e.g. "cardData" or "safetensors"
+	// (see the Expand constants)
+	Expand []string
+	// Full requests every property decoded into Model
+	Full bool
+	// Match, if set, filters models on the client after the server-side
+	// filters above; Limit then counts matching models only
+	Match func(Model) bool
+}
+
+// valueLists returns the multi-value filters
+func (o ListModelsOptions) valueLists() [][]string {
+	return [][]string{o.Filters, o.Tags, o.Languages, o.Libraries, o.PipelineTags}
+}
+
+// ServerFilters returns the values to send as filter= parameters: all the
+// multi-value filters, except those with several values in MatchAny mode
+// which are checked by ClientMatch instead
+func (o ListModelsOptions) ServerFilters() []string {
+	var filters []string
+	for _, values := range o.valueLists() {
+		if o.MatchAny && len(values) > 1 {
+			continue
+		}
+		filters = append(filters, values...)
+	}
+	return filters
+}
+
+// ClientMatch returns the predicate models are filtered with on the client,
+// combining Match and the MatchAny filters, or nil if there is none
+func (o ListModelsOptions) ClientMatch() func(Model) bool {
+	var anyOf [][]string
+	if o.MatchAny {
+		for _, values := range o.valueLists() {
+			if len(values) > 1 {
+				anyOf = append(anyOf, values)
+			}
+		}
+	}
+	if len(anyOf) == 0 {
+		return o.Match
+	}
+
+	match := o.Match
+	return func(m Model) bool {
+		for _, values := range anyOf {
+			if !slices.ContainsFunc(values, m.HasTag) {
+				return false
+			}
+		}
+		return match == nil || match(m)
+	}
+}
+
+// HasTag reports whether the model has tag, case-insensitively, as one of
+// its tags, its pipeline tag or its library, like the Hub's filter parameter
+func (m Model) HasTag(tag string) bool {
+	if strings.EqualFold(m.PipelineTag, tag) || strings.EqualFold(m.LibraryName, tag) {
+		return true
+	}
+	return slices.ContainsFunc(m.Tags, func(t string) bool {
+		return strings.EqualFold(t, tag)
+	})
+}
+
+// ExpandFields returns the properties to request with expand[], or nil to
+// let the Hub return its default ones
+func (o ListModelsOptions) ExpandFields() []string {
+	if o.Full {
+		return FullExpand
+	}
+	if len(o.Expand) == 0 {
+		return nil
+	}
+
+	fields := append([]string{}, BaseExpand...)
+	for _, f := range o.Expand {
+		if !slices.Contains(fields, f) {
+			fields = append(fields, f)
+		}
+	}
+	return fields
 }
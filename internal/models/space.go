@@ -0,0 +1,147 @@
+package models
+
+import (
+	"slices"
+	"strings"
+	"time"
+)
+
+// Space represents a Hugging Face Space in a listing. Models and Datasets
+// are only set for linked listings, Runtime when requested.
+type Space struct {
+	ID            string        `json:"id"`
+	Author        string        `json:"author"`
+	Likes         int           `json:"likes"`
+	LastModified  time.Time     `json:"lastModified"`
+	CreatedAt     time.Time     `json:"createdAt,omitzero"`
+	Private       bool          `json:"private"`
+	SDK           string        `json:"sdk,omitempty"`
+	TrendingScore float64       `json:"trending_score,omitempty"`
+	Tags          []string      `json:"tags,omitempty"`
+	Models        []string      `json:"models,omitempty"`
+	Datasets      []string      `json:"datasets,omitempty"`
+	Runtime       *SpaceRuntime `json:"runtime,omitempty"`
+}
+
+// UsesModel reports whether the Space lists modelID among its models,
+// case-insensitively
+func (s Space) UsesModel(modelID string) bool {
+	return slices.ContainsFunc(s.Models, func(m string) bool {
+		return strings.EqualFold(m, modelID)
+	})
+}
+
+// SpaceRuntime is the state of a Space: its stage and hardware
+type SpaceRuntime struct {
+	// Stage is e.g. "RUNNING", "SLEEPING", "BUILDING", "RUNTIME_ERROR" or "PAUSED"
+	Stage    string        `json:"stage"`
+	Hardware SpaceHardware `json:"hardware"`
+	// SleepTime is the number of seconds of inactivity before the Space sleeps
+	SleepTime int `json:"gcTimeout,omitempty"`
+}
+
+// SpaceHardware is the hardware a Space runs on, e.g. "cpu-basic" or "t4-small"
+type SpaceHardware struct {
+	Current   string `json:"current"`
+	Requested string `json:"requested"`
+}
+
+// Expandable Space properties of the Hub listing
+const (
+	SpaceExpandAuthor        = "author"
+	SpaceExpandCreatedAt     = "createdAt"
+	SpaceExpandDatasets      = "datasets"
+	SpaceExpandLastModified  = "lastModified"
+	SpaceExpandLikes         = "likes"
+	SpaceExpandModels        = "models"
+	SpaceExpandPrivate       = "private"
+	SpaceExpandRuntime       = "runtime"
+	SpaceExpandSDK           = "sdk"
+	SpaceExpandTags          = "tags"
+	SpaceExpandTrendingScore = "trendingScore"
+)
+
+// SpaceBaseExpand are the properties decoded into every Space, requested
+// along with the runtime since expand[] only returns the listed properties
+var SpaceBaseExpand = []string{
+	SpaceExpandAuthor, SpaceExpandLikes, SpaceExpandLastModified, SpaceExpandCreatedAt,
+	SpaceExpandPrivate, SpaceExpandSDK, SpaceExpandTags, SpaceExpandTrendingScore,
+}
+
+// ListSpacesOptions contains parameters for filtering and sorting Spaces.
+// The list filters take several values, which Spaces must all have.
+type ListSpacesOptions struct {
+	Search  string
+	Author  string
+	Filters []string
+	Tags    []string
+	// Models and Datasets select the Spaces using these repositories
+	Models   []string
+	Datasets []string
+	// Linked adds the models and datasets each Space uses
+	Linked bool
+	// Runtime adds the stage and hardware of each Space
+	Runtime   bool
+	Limit     int
+	Sort      string
+	Direction int
+	Token     string
+	// Match, if set, filters Spaces on the client after the server-side
+	// filters above; Limit then counts matching Spaces only
+	Match func(Space) bool
+}
+
+// ExpandFields returns the properties to request with expand[], or nil to
+// let the Hub return its default ones
+func (o ListSpacesOptions) ExpandFields() []string {
+	if !o.Runtime {
+		return nil
+	}
+	fields := append([]string{}, SpaceBaseExpand...)
+	fields = append(fields, SpaceExpandRuntime)
+	if o.Linked || len(o.Models) > 0 || len(o.Datasets) > 0 {
+		fields = append(fields, SpaceExpandModels, SpaceExpandDatasets)
+	}
+	return fields
+}
+
+// SpaceDetails contains detailed Space information including files
+type SpaceDetails struct {
+	ID           string        `json:"id"`
+	SHA          string        `json:"sha"`
+	Author       string        `json:"author"`
+	Likes        int           `json:"likes"`
+	CreatedAt    time.Time     `json:"createdAt"`
+	LastModified time.Time     `json:"lastModified"`
+	Private      bool          `json:"private"`
+	Gated        Gated         `json:"gated"`
+	Disabled     bool          `json:"disabled"`
+	SDK          string        `json:"sdk"`
+	Host         string        `json:"host,omitempty"`
+	Subdomain    string        `json:"subdomain,omitempty"`
+	Tags         []string      `json:"tags"`
+	Models       []string      `json:"models,omitempty"`
+	Datasets     []string      `json:"datasets,omitempty"`
+	Runtime      *SpaceRuntime `json:"runtime,omitempty"`
+	Siblings     []Sibling     `json:"siblings"`
+	CardData     SpaceCardData `json:"cardData"`
+}
+
+// SpaceCardData contains the metadata of a Space's README
+type SpaceCardData struct {
+	Title            string      `json:"title,omitempty"`
+	Emoji            string      `json:"emoji,omitempty"`
+	SDK              string      `json:"sdk,omitempty"`
+	SDKVersion       string      `json:"sdk_version,omitempty"`
+	AppFile          string      `json:"app_file,omitempty"`
+	ShortDescription string      `json:"short_description,omitempty"`
+	License          interface{} `json:"license,omitempty"`
+}
+
+// GetLicense returns the license (the first one if several)
+func (c SpaceCardData) GetLicense() string {
+	if licenses := stringList(c.License); len(licenses) > 0 {
+		return licenses[0]
+	}
+	return ""
+}
@@ -0,0 +1,39 @@
+package models
+
+import "time"
+
+// Repository tree entry types
+const (
+	TreeEntryFile      = "file"
+	TreeEntryDirectory = "directory"
+)
+
+// RepoTreeEntry is a file or directory in a repository tree listing
+type RepoTreeEntry struct {
+	Type       string          `json:"type"`
+	Path       string          `json:"path"`
+	Size       int64           `json:"size"`
+	OID        string          `json:"oid"`
+	LFS        *LFSInfo        `json:"lfs,omitempty"`
+	LastCommit *LastCommitInfo `json:"lastCommit,omitempty"`
+}
+
+// IsDir reports whether the entry is a directory
+func (e RepoTreeEntry) IsDir() bool {
+	return e.Type == TreeEntryDirectory
+}
+
+// LFSInfo describes a file stored with Git LFS
+type LFSInfo struct {
+	// OID is the SHA256 of the file contents
+	OID         string `json:"oid"`
+	Size        int64  `json:"size"`
+	PointerSize int64  `json:"pointerSize"`
+}
+
+// LastCommitInfo describes the last commit touching a tree entry
+type LastCommitInfo struct {
+	ID    string    `json:"id"`
+	Title string    `json:"title"`
+	Date  time.Time `json:"date"`
+}
@@ -0,0 +1,74 @@
+package utils
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/Megatherium/hf-go/internal/models"
+)
+
+// DatasetColumns are the columns available in the dataset table, selected by
+// name with SelectColumns, with counts in the comma style
+var DatasetColumns = DatasetColumnsWith(CommaNumber)
+
+// DatasetColumnsWith returns the columns of the dataset table with counts
+// displayed in the given style
+func DatasetColumnsWith(number NumberFormat) []Column[models.Dataset] {
+	return []Column[models.Dataset]{
+		{Name: "id", Header: "Dataset ID", Value: func(d models.Dataset) string { return d.ID }},
+		{Name: "author", Header: "Author", Value: func(d models.Dataset) string { return d.Author }},
+		{Name: "downloads", Header: "Downloads", Value: func(d models.Dataset) string { return strconv.Itoa(d.Downloads) },
+			Display: func(d models.Dataset) string { return number(d.Downloads) }},
+		{Name: "likes", Header: "Likes", Value: func(d models.Dataset) string { return strconv.Itoa(d.Likes) },
+			Display: func(d models.Dataset) string { return number(d.Likes) }},
+		{Name: "last_modified", Header: "Last Modified", Value: func(d models.Dataset) string { return formatTime(d.LastModified) },
+			Display: func(d models.Dataset) string { return OrNA(FormatDate(d.LastModified)) }},
+		{Name: "task_categories", Header: "Tasks", Value: func(d models.Dataset) string { return strings.Join(d.TaskCategories(), ",") },
+			Display: func(d models.Dataset) string { return OrNA(strings.Join(d.TaskCategories(), ", ")) }},
+		{Name: "size_categories", Header: "Size", Value: func(d models.Dataset) string { return strings.Join(d.SizeCategories(), ",") },
+			Display: func(d models.Dataset) string { return OrNA(strings.Join(d.SizeCategories(), ", ")) }},
+		{Name: "languages", Header: "Languages", Value: func(d models.Dataset) string { return strings.Join(d.Languages(), ",") },
+			Display: func(d models.Dataset) string { return OrNA(strings.Join(d.Languages(), ", ")) }},
+		{Name: "license", Header: "License", Value: func(d models.Dataset) string { return d.License() },
+			Display: func(d models.Dataset) string { return OrNA(d.License()) }},
+		{Name: "trending_score", Header: "Trending", Value: func(d models.Dataset) string {
+			return strconv.FormatFloat(d.TrendingScore, 'f', -1, 64)
+		}},
+		{Name: "private", Header: "Private", Value: func(d models.Dataset) string { return strconv.FormatBool(d.Private) },
+			Display: func(d models.Dataset) string { return formatBool(d.Private) }},
+		{Name: "gated", Header: "Gated", Value: func(d models.Dataset) string { return strconv.FormatBool(d.Gated) },
+			Display: func(d models.Dataset) string { return formatBool(d.Gated) }},
+		{Name: "created_at", Header: "Created", Value: func(d models.Dataset) string { return formatTime(d.CreatedAt) },
+			Display: func(d models.Dataset) string { return OrNA(FormatDate(d.CreatedAt)) }},
+		{Name: "sha", Header: "SHA", Value: func(d models.Dataset) string { return d.SHA }},
+		{Name: "tags", Header: "Tags", Value: func(d models.Dataset) string { return strings.Join(d.Tags, ",") },
+			Display: func(d models.Dataset) string { return strings.Join(d.Tags, ", ") }},
+	}
+}
+
+// DefaultDatasetColumns are the columns of the dataset table unless others
+// are selected
+var DefaultDatasetColumns = []string{"id", "author", "downloads", "likes", "last_modified", "task_categories", "size_categories"}
+
+// DatasetTable builds the table of datasets written by every output format,
+// with the default columns
+func DatasetTable(datasets []models.Dataset) Table {
+	columns, _ := SelectColumns(DatasetColumns, DefaultDatasetColumns)
+	return DatasetTableColumns(datasets, columns)
+}
+
+// DatasetTableColumns is like DatasetTable with the given columns
+func DatasetTableColumns(datasets []models.Dataset, columns []Column[models.Dataset]) Table {
+	t := NewTable(datasets, columns)
+	t.Empty = "No datasets found matching the specified criteria."
+	t.RowColors = make([]string, len(datasets))
+	for i, d := range datasets {
+		switch {
+		case d.Private:
+			t.RowColors[i] = ColorPrivate
+		case d.Gated:
+			t.RowColors[i] = ColorGated
+		}
+	}
+	return t
+}
@@ -1,64 +1,144 @@
 package utils
 
 import (
-	"encoding/json"
 	"fmt"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/Megatherium/hf-go/internal/models"
 )
 
-// FormatTable formats models as a pretty-printed table
-func FormatTable(modelsList []models.Model) string {
-	if len(modelsList) == 0 {
-		return "No models found matching the specified criteria."
+// ModelColumns are the columns available in the model table, selected by
+// name with SelectColumns, with counts in the comma style
+var ModelColumns = ModelColumnsWith(CommaNumber)
+
+// ModelColumnsWith returns the columns of the model table with counts
+// displayed in the given style
+func ModelColumnsWith(number NumberFormat) []Column[models.Model] {
+	return []Column[models.Model]{
+		{Name: "id", Header: "Model ID", Value: func(m models.Model) string { return m.ID }},
+		{Name: "author", Header: "Author", Value: func(m models.Model) string { return m.Author }},
+		{Name: "downloads", Header: "Downloads", Value: func(m models.Model) string { return strconv.Itoa(m.Downloads) },
+			Display: func(m models.Model) string { return number(m.Downloads) }},
+		{Name: "likes", Header: "Likes", Value: func(m models.Model) string { return strconv.Itoa(m.Likes) },
+			Display: func(m models.Model) string { return number(m.Likes) }},
+		{Name: "last_modified", Header: "Last Modified", Value: func(m models.Model) string { return formatTime(m.LastModified) },
+			Display: func(m models.Model) string { return OrNA(FormatDate(m.LastModified)) }},
+		{Name: "library", Header: "Library", Value: func(m models.Model) string { return m.LibraryName },
+			Display: func(m models.Model) string { return OrNA(m.LibraryName) }},
+		{Name: "task", Header: "Task", Value: func(m models.Model) string { return m.PipelineTag },
+			Display: func(m models.Model) string { return OrNA(m.PipelineTag) }},
+		{Name: "trending_score", Header: "Trending", Value: func(m models.Model) string {
+			return strconv.FormatFloat(m.TrendingScore, 'f', -1, 64)
+		}},
+		{Name: "private", Header: "Private", Value: func(m models.Model) string { return strconv.FormatBool(m.Private) },
+			Display: func(m models.Model) string { return formatBool(m.Private) }},
+		{Name: "gated", Header: "Gated", Value: func(m models.Model) string { return strconv.FormatBool(m.Gated) },
+			Display: func(m models.Model) string { return formatBool(m.Gated) }},
+		{Name: "created_at", Header: "Created", Value: func(m models.Model) string { return formatTime(m.CreatedAt) },
+			Display: func(m models.Model) string { return OrNA(FormatDate(m.CreatedAt)) }},
+		{Name: "downloads_all_time", Header: "All-Time Downloads", Value: func(m models.Model) string {
+			return strconv.Itoa(m.DownloadsAllTime)
+		}, Display: func(m models.Model) string { return number(m.DownloadsAllTime) }},
+		{Name: "license", Header: "License", Value: func(m models.Model) string { return m.License() },
+			Display: func(m models.Model) string { return OrNA(m.License()) }},
+		{Name: "parameters", Header: "Parameters", Value: func(m models.Model) string {
+			return strconv.FormatInt(m.ParameterCount(), 10)
+		}, Display: func(m models.Model) string {
+			if m.ParameterCount() <= 0 {
+				return "N/A"
+			}
+			return FormatCount(uint64(m.ParameterCount()))
+		}},
+		{Name: "sha", Header: "SHA", Value: func(m models.Model) string { return m.SHA }},
+		{Name: "tags", Header: "Tags", Value: func(m models.Model) string { return strings.Join(m.Tags, ",") },
+			Display: func(m models.Model) string { return strings.Join(m.Tags, ", ") }},
 	}
+}
 
-	// Headers
-	headers := []string{"Model ID", "Author", "Downloads", "Likes", "Last Modified", "Library", "Task"}
+// DefaultModelColumns are the columns of the model table unless others are
+// selected
+var DefaultModelColumns = []string{"id", "author", "downloads", "likes", "last_modified", "library", "task"}
 
-	// Calculate column widths
-	widths := make([]int, len(headers))
-	for i, h := range headers {
-		widths[i] = len(h)
-	}
+// ModelTable builds the table of models written by every output format,
+// with the default columns
+func ModelTable(modelsList []models.Model) Table {
+	columns, _ := SelectColumns(ModelColumns, DefaultModelColumns)
+	return ModelTableColumns(modelsList, columns)
+}
 
-	// Prepare rows and update column widths
-	rows := make([][]string, len(modelsList))
-	for i, model := range modelsList {
-		lastModified := "N/A"
-		if !model.LastModified.IsZero() {
-			lastModified = model.LastModified.Format("2006-01-02")
+// ModelTableColumns is like ModelTable with the given columns
+func ModelTableColumns(modelsList []models.Model, columns []Column[models.Model]) Table {
+	t := NewTable(modelsList, columns)
+	t.Empty = "No models found matching the specified criteria."
+	t.RowColors = make([]string, len(modelsList))
+	for i, m := range modelsList {
+		switch {
+		case m.Private:
+			t.RowColors[i] = ColorPrivate
+		case m.Gated:
+			t.RowColors[i] = ColorGated
 		}
+	}
+	return t
+}
 
-		library := model.LibraryName
-		if library == "" {
-			library = "N/A"
-		}
+// FormatModels formats models in the named output format
+func FormatModels(modelsList []models.Model, format string) (string, error) {
+	return FormatString(format, ModelTable(modelsList))
+}
 
-		task := model.PipelineTag
-		if task == "" {
-			task = "N/A"
-		}
+// FormatTable formats models as a pretty-printed table
+func FormatTable(modelsList []models.Model) string {
+	output, _ := FormatModels(modelsList, "table")
+	return output
+}
 
-		row := []string{
-			model.ID,
-			model.Author,
-			formatNumber(model.Downloads),
-			formatNumber(model.Likes),
-			lastModified,
-			library,
-			task,
-		}
-		rows[i] = row
+// RenderOptions control how RenderTableWith fits a table in the terminal
+// and colours it
+type RenderOptions struct {
+	// MaxWidth is the width lines must fit in, shrinking the widest columns
+	// and ellipsizing their cells; 0 means no limit
+	MaxWidth int
+	// Color enables ANSI colours: bold headers, and RowColors
+	Color bool
+	// RowColors are ANSI SGR parameters per row, e.g. "33" for yellow, or ""
+	RowColors []string
+}
+
+// ANSI SGR parameters of table rows
+const (
+	ColorGated   = "33" // yellow
+	ColorPrivate = "35" // magenta
+)
+
+// minColumnWidth is the width columns with a shorter header are never
+// shrunk below to fit the terminal
+const minColumnWidth = 8
+
+// RenderTable renders headers and rows as a pretty-printed table
+func RenderTable(headers []string, rows [][]string) string {
+	return RenderTableWith(headers, rows, RenderOptions{})
+}
 
-		// Update widths
+// RenderTableWith is like RenderTable with options
+func RenderTableWith(headers []string, rows [][]string, opts RenderOptions) string {
+	// Calculate column widths in terminal columns, not bytes
+	widths := make([]int, len(headers))
+	for i, h := range headers {
+		widths[i] = DisplayWidth(h)
+	}
+	for _, row := range rows {
 		for j, cell := range row {
-			if len(cell) > widths[j] {
-				widths[j] = len(cell)
+			if w := DisplayWidth(cell); w > widths[j] {
+				widths[j] = w
 			}
 		}
 	}
+	if opts.MaxWidth > 0 {
+		fitWidths(widths, headers, opts.MaxWidth)
+	}
 
 	// Build table
 	var sb strings.Builder
@@ -68,23 +148,23 @@ func FormatTable(modelsList []models.Model) string {
 	sb.WriteString("\n")
 
 	// Print headers
-	sb.WriteString("│")
-	for i, header := range headers {
-		sb.WriteString(fmt.Sprintf(" %-*s │", widths[i], header))
+	color := ""
+	if opts.Color {
+		color = "1"
 	}
-	sb.WriteString("\n")
+	sb.WriteString(renderRow(headers, widths, color))
 
 	// Print header separator
 	sb.WriteString(buildSeparator(widths))
 	sb.WriteString("\n")
 
 	// Print rows
-	for _, row := range rows {
-		sb.WriteString("│")
-		for i, cell := range row {
-			sb.WriteString(fmt.Sprintf(" %-*s │", widths[i], cell))
+	for i, row := range rows {
+		color := ""
+		if opts.Color && i < len(opts.RowColors) {
+			color = opts.RowColors[i]
 		}
-		sb.WriteString("\n")
+		sb.WriteString(renderRow(row, widths, color))
 	}
 
 	// Print footer separator
@@ -93,6 +173,48 @@ func FormatTable(modelsList []models.Model) string {
 	return sb.String()
 }
 
+// renderRow renders a line of cells, truncated to the column widths and
+// coloured with the SGR parameters color unless empty
+func renderRow(cells []string, widths []int, color string) string {
+	var sb strings.Builder
+	sb.WriteString("│")
+	for i, cell := range cells {
+		cell = PadRight(Truncate(cell, widths[i]), widths[i])
+		if color != "" {
+			cell = "\x1b[" + color + "m" + cell + "\x1b[0m"
+		}
+		sb.WriteString(" " + cell + " │")
+	}
+	sb.WriteString("\n")
+	return sb.String()
+}
+
+// fitWidths shrinks the widest columns until a table with these column
+// widths fits in maxWidth terminal columns. Columns are not shrunk below
+// their header or minColumnWidth, so a table with many columns may still
+// overflow.
+func fitWidths(widths []int, headers []string, maxWidth int) {
+	floors := make([]int, len(widths))
+	total := 1
+	for i, w := range widths {
+		floors[i] = min(w, max(minColumnWidth, DisplayWidth(headers[i])))
+		total += w + 3
+	}
+	for total > maxWidth {
+		widest := -1
+		for i, w := range widths {
+			if w > floors[i] && (widest < 0 || w > widths[widest]) {
+				widest = i
+			}
+		}
+		if widest < 0 {
+			return
+		}
+		widths[widest]--
+		total--
+	}
+}
+
 // buildSeparator creates a table separator line
 func buildSeparator(widths []int) string {
 	var sb strings.Builder
@@ -107,6 +229,25 @@ func buildSeparator(widths []int) string {
 	return sb.String()
 }
 
+// NumberFormat formats counts such as downloads and likes for display
+type NumberFormat func(n int) string
+
+// Number formats
+var (
+	// CommaNumber writes thousands separators: 1,234,567
+	CommaNumber NumberFormat = formatNumber
+	// CompactNumber abbreviates with K, M and B: 1.23M
+	CompactNumber NumberFormat = formatCompact
+)
+
+// formatCompact formats an integer like FormatCount, e.g. 1.23M or 345K
+func formatCompact(n int) string {
+	if n < 0 {
+		return "-" + FormatCount(uint64(-n))
+	}
+	return FormatCount(uint64(n))
+}
+
 // formatNumber formats an integer with thousands separators
 func formatNumber(n int) string {
 	str := fmt.Sprintf("%d", n)
@@ -126,14 +267,38 @@ func formatNumber(n int) string {
 
 // FormatJSON formats models as JSON
 func FormatJSON(modelsList []models.Model) (string, error) {
-	if len(modelsList) == 0 {
-		return "[]", nil
+	return FormatModels(modelsList, "json")
+}
+
+// FormatDate formats a date as YYYY-MM-DD, or "" if it is not set. Wrap it
+// in OrNA for tables.
+func FormatDate(t time.Time) string {
+	if t.IsZero() {
+		return ""
 	}
+	return t.Format("2006-01-02")
+}
 
-	output, err := json.MarshalIndent(modelsList, "", "  ")
-	if err != nil {
-		return "", fmt.Errorf("failed to marshal JSON: %w", err)
+// formatTime formats a time as RFC 3339, or "" if it is not set
+func formatTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
 	}
+	return t.Format(time.RFC3339)
+}
 
-	return string(output), nil
+// formatBool formats a flag for tables
+func formatBool(b bool) string {
+	if b {
+		return "yes"
+	}
+	return "no"
+}
+
+// OrNA returns s, or "N/A" if it is empty
+func OrNA(s string) string {
+	if s == "" {
+		return "N/A"
+	}
+	return s
 }
@@ -0,0 +1,302 @@
+package utils
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"text/template"
+)
+
+// Table is a list of records ready to be written in any output format: a
+// header and a row of cells per record for the tabular formats, and the
+// records themselves for JSON, YAML and NDJSON
+type Table struct {
+	Headers []string
+	// Rows are the cells as displayed in the table and Markdown formats
+	Rows [][]string
+	// Values are the cells without display formatting, for CSV and TSV
+	Values [][]string
+	// Records are marshalled as they are by the structured formats
+	Records []interface{}
+	// Empty is printed by the table format instead of an empty table
+	Empty string
+	// RowColors are ANSI SGR parameters per row, e.g. ColorGated
+	RowColors []string
+	// MaxWidth and Color fit and colour the table format for a terminal,
+	// see RenderOptions
+	MaxWidth int
+	Color    bool
+}
+
+// Column describes a column of a Table built from records of type T
+type Column[T any] struct {
+	// Name selects the column, e.g. in --columns
+	Name   string
+	Header string
+	// Value returns the cell of a record, as written by CSV and TSV
+	Value func(T) string
+	// Display returns the cell as shown in tables; Value is used if nil
+	Display func(T) string
+}
+
+// NewTable builds a Table with the given columns from records
+func NewTable[T any](records []T, columns []Column[T]) Table {
+	t := Table{
+		Headers: make([]string, len(columns)),
+		Rows:    make([][]string, len(records)),
+		Values:  make([][]string, len(records)),
+		Records: make([]interface{}, len(records)),
+	}
+	for i, c := range columns {
+		t.Headers[i] = c.Header
+	}
+	for i, r := range records {
+		t.Rows[i] = make([]string, len(columns))
+		t.Values[i] = make([]string, len(columns))
+		for j, c := range columns {
+			t.Values[i][j] = c.Value(r)
+			t.Rows[i][j] = t.Values[i][j]
+			if c.Display != nil {
+				t.Rows[i][j] = c.Display(r)
+			}
+		}
+		t.Records[i] = r
+	}
+	return t
+}
+
+// SelectColumns returns the columns named by names, in that order. Names
+// are matched case-insensitively and ignoring "_" and "-", so
+// "trendingScore" selects the "trending_score" column.
+func SelectColumns[T any](all []Column[T], names []string) ([]Column[T], error) {
+	selected := make([]Column[T], 0, len(names))
+	for _, name := range names {
+		i := slices.IndexFunc(all, func(c Column[T]) bool {
+			return columnKey(c.Name) == columnKey(name)
+		})
+		if i < 0 {
+			known := make([]string, len(all))
+			for j, c := range all {
+				known[j] = c.Name
+			}
+			return nil, fmt.Errorf("unknown column %q (use %s)", name, strings.Join(known, ", "))
+		}
+		selected = append(selected, all[i])
+	}
+	return selected, nil
+}
+
+func columnKey(name string) string {
+	return strings.ToLower(strings.NewReplacer("_", "", "-", "", " ", "").Replace(strings.TrimSpace(name)))
+}
+
+// Formatter writes a table in an output format
+type Formatter func(w io.Writer, t Table) error
+
+// Format is a registered output format
+type Format struct {
+	Name  string
+	Write Formatter
+	// Streaming formats can be written once per page of a listing as the
+	// pages arrive; the output is the same as for the whole listing
+	Streaming bool
+}
+
+var (
+	formatsMu sync.RWMutex
+	formats   = map[string]Format{}
+)
+
+// RegisterFormat adds an output format, replacing any format of that name
+func RegisterFormat(f Format) {
+	formatsMu.Lock()
+	defer formatsMu.Unlock()
+	formats[f.Name] = f
+}
+
+// LookupFormat returns the output format registered under name
+func LookupFormat(name string) (Format, error) {
+	formatsMu.RLock()
+	defer formatsMu.RUnlock()
+	f, ok := formats[name]
+	if !ok {
+		return Format{}, fmt.Errorf("unsupported output format: %s (use %s)", name, strings.Join(formatNames(), ", "))
+	}
+	return f, nil
+}
+
+// FormatNames returns the names of the registered output formats, sorted
+func FormatNames() []string {
+	formatsMu.RLock()
+	defer formatsMu.RUnlock()
+	return formatNames()
+}
+
+func formatNames() []string {
+	names := make([]string, 0, len(formats))
+	for name := range formats {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// WriteTable writes t to w in the named output format
+func WriteTable(w io.Writer, format string, t Table) error {
+	f, err := LookupFormat(format)
+	if err != nil {
+		return err
+	}
+	return f.Write(w, t)
+}
+
+// FormatString returns t in the named output format, without the final newline
+func FormatString(format string, t Table) (string, error) {
+	var sb strings.Builder
+	if err := WriteTable(&sb, format, t); err != nil {
+		return "", err
+	}
+	return strings.TrimSuffix(sb.String(), "\n"), nil
+}
+
+// templateFuncs are the functions available to templates besides the
+// text/template builtins
+var templateFuncs = template.FuncMap{
+	"join":   strings.Join,
+	"number": formatNumber,
+	"date":   FormatDate,
+	"json": func(v interface{}) (string, error) {
+		var sb strings.Builder
+		enc := json.NewEncoder(&sb)
+		enc.SetEscapeHTML(false)
+		err := enc.Encode(v)
+		return strings.TrimSuffix(sb.String(), "\n"), err
+	},
+}
+
+// TemplateFormat returns a format executing a text/template for every
+// record, each followed by a newline, e.g. "{{.ID}} {{.Downloads}}". Besides
+// the builtins, templates can call join, number (thousands separators), date
+// (YYYY-MM-DD) and json.
+func TemplateFormat(text string) (Format, error) {
+	tmpl, err := template.New("output").Funcs(templateFuncs).Parse(text)
+	if err != nil {
+		return Format{}, fmt.Errorf("invalid template: %w", err)
+	}
+
+	write := func(w io.Writer, t Table) error {
+		for _, r := range t.Records {
+			if err := tmpl.Execute(w, r); err != nil {
+				return fmt.Errorf("failed to execute template: %w", err)
+			}
+			if _, err := io.WriteString(w, "\n"); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	return Format{Name: "template", Write: write, Streaming: true}, nil
+}
+
+func init() {
+	RegisterFormat(Format{Name: "table", Write: writeTable})
+	RegisterFormat(Format{Name: "json", Write: writeJSON})
+	RegisterFormat(Format{Name: "ndjson", Write: writeNDJSON, Streaming: true})
+	RegisterFormat(Format{Name: "yaml", Write: writeYAML})
+	RegisterFormat(Format{Name: "csv", Write: delimitedWriter(',')})
+	RegisterFormat(Format{Name: "tsv", Write: delimitedWriter('\t')})
+	RegisterFormat(Format{Name: "markdown", Write: writeMarkdown})
+}
+
+func writeTable(w io.Writer, t Table) error {
+	if len(t.Rows) == 0 && t.Empty != "" {
+		_, err := fmt.Fprintln(w, t.Empty)
+		return err
+	}
+	_, err := fmt.Fprintln(w, RenderTableWith(t.Headers, t.Rows, RenderOptions{
+		MaxWidth:  t.MaxWidth,
+		Color:     t.Color,
+		RowColors: t.RowColors,
+	}))
+	return err
+}
+
+func writeJSON(w io.Writer, t Table) error {
+	records := t.Records
+	if records == nil {
+		records = []interface{}{}
+	}
+	return WriteJSON(w, records)
+}
+
+// WriteJSON writes v as indented JSON followed by a newline, like the json
+// output format, for values that aren't listings
+func WriteJSON(w io.Writer, v interface{}) error {
+	enc := json.NewEncoder(w)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(v); err != nil {
+		return fmt.Errorf("failed to marshal JSON: %w", err)
+	}
+	return nil
+}
+
+// writeNDJSON writes one JSON record per line. Neither JSON format escapes
+// HTML characters, which appear in tags such as "size_categories:10K<n<100K".
+func writeNDJSON(w io.Writer, t Table) error {
+	enc := json.NewEncoder(w)
+	enc.SetEscapeHTML(false)
+	for _, r := range t.Records {
+		if err := enc.Encode(r); err != nil {
+			return fmt.Errorf("failed to marshal JSON: %w", err)
+		}
+	}
+	return nil
+}
+
+// delimitedWriter returns a formatter writing a header row and the values
+// separated by comma, quoted as needed
+func delimitedWriter(comma rune) Formatter {
+	return func(w io.Writer, t Table) error {
+		cw := csv.NewWriter(w)
+		cw.Comma = comma
+		if err := cw.Write(t.Headers); err != nil {
+			return err
+		}
+		if err := cw.WriteAll(t.Values); err != nil {
+			return fmt.Errorf("failed to write records: %w", err)
+		}
+		return nil
+	}
+}
+
+// writeMarkdown writes a GitHub-flavoured Markdown table
+func writeMarkdown(w io.Writer, t Table) error {
+	var sb strings.Builder
+	writeMarkdownRow(&sb, t.Headers)
+	separator := make([]string, len(t.Headers))
+	for i := range separator {
+		separator[i] = "---"
+	}
+	writeMarkdownRow(&sb, separator)
+	for _, row := range t.Rows {
+		writeMarkdownRow(&sb, row)
+	}
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
+
+func writeMarkdownRow(sb *strings.Builder, cells []string) {
+	sb.WriteString("|")
+	for _, cell := range cells {
+		cell = strings.ReplaceAll(cell, "|", `\|`)
+		cell = strings.ReplaceAll(cell, "\n", " ")
+		sb.WriteString(" " + cell + " |")
+	}
+	sb.WriteString("\n")
+}
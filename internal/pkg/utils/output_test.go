@@ -0,0 +1,559 @@
+package utils
+
+import (
+	"io"
+	"os"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Megatherium/hf-go/internal/models"
+)
+
+// record has fields of every kind the output formats handle
+type record struct {
+	ID     string            `json:"id"`
+	Count  int               `json:"count"`
+	Score  float64           `json:"score,omitempty"`
+	Tags   []string          `json:"tags"`
+	Extra  map[string]string `json:"extra,omitempty"`
+	Nested []child           `json:"nested,omitempty"`
+}
+
+type child struct {
+	Name  string   `json:"name"`
+	Items []string `json:"items"`
+}
+
+func TestWriteYAML(t *testing.T) {
+	tests := []struct {
+		name    string
+		records []interface{}
+		want    string
+	}{
+		{
+			name:    "no records",
+			records: nil,
+			want:    "[]\n",
+		},
+		{
+			name: "field order and scalars",
+			records: []interface{}{
+				record{ID: "org/model", Count: 1200, Score: 0.5, Tags: []string{"gguf", "text-generation"}},
+			},
+			want: `- id: org/model
+  count: 1200
+  score: 0.5
+  tags:
+    - gguf
+    - text-generation
+`,
+		},
+		{
+			name: "empty collections",
+			records: []interface{}{
+				record{ID: "a", Tags: []string{}},
+				map[string]interface{}{},
+				[]interface{}{},
+			},
+			want: `- id: a
+  count: 0
+  tags: []
+- {}
+- []
+`,
+		},
+		{
+			name: "nested objects in sequences",
+			records: []interface{}{
+				record{ID: "a", Tags: nil, Nested: []child{
+					{Name: "x", Items: []string{"1"}},
+					{Name: "y", Items: []string{}},
+				}},
+				[]interface{}{[]interface{}{"a", "b"}, map[string]interface{}{"k": map[string]interface{}{"v": nil}}},
+			},
+			want: `- id: a
+  count: 0
+  tags: null
+  nested:
+    - name: x
+      items:
+        - "1"
+    - name: "y"
+      items: []
+-
+  -
+    - a
+    - b
+  - k:
+      v: null
+`,
+		},
+		{
+			name: "strings that look like other types",
+			records: []interface{}{
+				map[string]interface{}{"v": []interface{}{
+					"true", "False", "yes", "no", "on", "off", "y", "N", "null", "~", "",
+					"1", "1.5", "-2", "1e3", "0x1F", "0o17", "1_000", ".inf", ".nan",
+					"key: value", "trailing:", "a #comment", "- item", "[list]", "{map}",
+					"*alias", "&anchor", "!tag", "|block", ">folded", "'single'", `"double"`,
+					"%directive", "@reserved", "`tick`", " padded", "padded ", "line\nbreak", "tab\there",
+					"plain text", "org/model", "license:mit", "10K<n<100K", "a-b",
+				}},
+			},
+			want: `- v:
+    - "true"
+    - "False"
+    - "yes"
+    - "no"
+    - "on"
+    - "off"
+    - "y"
+    - "N"
+    - "null"
+    - "~"
+    - ""
+    - "1"
+    - "1.5"
+    - "-2"
+    - "1e3"
+    - "0x1F"
+    - "0o17"
+    - "1_000"
+    - ".inf"
+    - ".nan"
+    - "key: value"
+    - "trailing:"
+    - "a #comment"
+    - "- item"
+    - "[list]"
+    - "{map}"
+    - "*alias"
+    - "&anchor"
+    - "!tag"
+    - "|block"
+    - ">folded"
+    - "'single'"
+    - "\"double\""
+    - "%directive"
+    - "@reserved"
+    - "` + "`tick`" + `"
+    - " padded"
+    - "padded "
+    - "line\nbreak"
+    - "tab\there"
+    - plain text
+    - org/model
+    - license:mit
+    - 10K<n<100K
+    - a-b
+`,
+		},
+		{
+			name: "keys needing quotes",
+			records: []interface{}{
+				map[string]interface{}{"a: b": 1, "true": false, "plain": true},
+			},
+			want: `- "a: b": 1
+  plain: true
+  "true": false
+`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := FormatString("yaml", Table{Records: tt.records})
+			if err != nil {
+				t.Fatalf("FormatString error: %v", err)
+			}
+			if got+"\n" != tt.want {
+				t.Errorf("got:\n%s\nwant:\n%s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWriteDelimited(t *testing.T) {
+	table := Table{
+		Headers: []string{"ID", "Tags", "Note"},
+		Values: [][]string{
+			{"org/a", "gguf,text-generation", `say "hi"`},
+			{"org/b", "", "tab\there"},
+			{"org/c", "x", "line\nbreak"},
+		},
+		// The display cells are not used by CSV and TSV
+		Rows: [][]string{{"-", "-", "-"}, {"-", "-", "-"}, {"-", "-", "-"}},
+	}
+
+	tests := []struct {
+		format string
+		want   string
+	}{
+		{"csv", `ID,Tags,Note
+org/a,"gguf,text-generation","say ""hi"""
+org/b,,tab	here
+org/c,x,"line
+break"
+`},
+		{"tsv", `ID	Tags	Note
+org/a	gguf,text-generation	"say ""hi"""
+org/b		"tab	here"
+org/c	x	"line
+break"
+`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			var sb strings.Builder
+			if err := WriteTable(&sb, tt.format, table); err != nil {
+				t.Fatalf("WriteTable error: %v", err)
+			}
+			if sb.String() != tt.want {
+				t.Errorf("got:\n%s\nwant:\n%s", sb.String(), tt.want)
+			}
+		})
+	}
+
+	var sb strings.Builder
+	if err := WriteTable(&sb, "csv", Table{Headers: []string{"ID"}}); err != nil || sb.String() != "ID\n" {
+		t.Errorf("empty CSV = %q, %v; want only the header", sb.String(), err)
+	}
+}
+
+func TestWriteNDJSON(t *testing.T) {
+	table := Table{Records: []interface{}{
+		record{ID: "org/a", Count: 1, Tags: []string{"x"}},
+		record{ID: "org/<b>", Count: 2, Extra: map[string]string{"k": "line\nbreak"}},
+	}}
+	want := `{"id":"org/a","count":1,"tags":["x"]}
+{"id":"org/<b>","count":2,"tags":null,"extra":{"k":"line\nbreak"}}
+`
+	var sb strings.Builder
+	if err := WriteTable(&sb, "ndjson", table); err != nil {
+		t.Fatalf("WriteTable error: %v", err)
+	}
+	if sb.String() != want {
+		t.Errorf("got:\n%s\nwant:\n%s", sb.String(), want)
+	}
+
+	// Streaming: writing page by page gives the same output
+	var paged strings.Builder
+	for _, r := range table.Records {
+		if err := WriteTable(&paged, "ndjson", Table{Records: []interface{}{r}}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if paged.String() != want {
+		t.Errorf("paged output differs:\n%s", paged.String())
+	}
+
+	sb.Reset()
+	if err := WriteTable(&sb, "ndjson", Table{}); err != nil || sb.String() != "" {
+		t.Errorf("empty NDJSON = %q, %v; want nothing", sb.String(), err)
+	}
+}
+
+func TestWriteJSON(t *testing.T) {
+	want := `[
+  {
+    "id": "size_categories:10K<n<100K",
+    "count": 1,
+    "tags": [
+      "a&b"
+    ]
+  }
+]
+`
+	var sb strings.Builder
+	if err := WriteTable(&sb, "json", Table{Records: []interface{}{record{ID: "size_categories:10K<n<100K", Count: 1, Tags: []string{"a&b"}}}}); err != nil {
+		t.Fatalf("WriteTable error: %v", err)
+	}
+	if sb.String() != want {
+		t.Errorf("got:\n%s\nwant:\n%s", sb.String(), want)
+	}
+
+	sb.Reset()
+	if err := WriteTable(&sb, "json", Table{}); err != nil || sb.String() != "[]\n" {
+		t.Errorf("empty JSON = %q, %v; want []", sb.String(), err)
+	}
+
+	// Single objects are printed the same way as listings
+	sb.Reset()
+	if err := WriteJSON(&sb, map[string]string{"size": "10K<n<100K"}); err != nil {
+		t.Fatalf("WriteJSON error: %v", err)
+	}
+	if want := "{\n  \"size\": \"10K<n<100K\"\n}\n"; sb.String() != want {
+		t.Errorf("WriteJSON = %q, want %q", sb.String(), want)
+	}
+	if err := WriteJSON(io.Discard, func() {}); err == nil {
+		t.Error("WriteJSON of a func succeeded")
+	}
+
+	f, err := TemplateFormat("{{json .Tags}}")
+	if err != nil {
+		t.Fatalf("TemplateFormat error: %v", err)
+	}
+	sb.Reset()
+	if err := f.Write(&sb, Table{Records: []interface{}{record{Tags: []string{"a&b", "<c>"}}}}); err != nil {
+		t.Fatalf("Write error: %v", err)
+	}
+	if want := `["a&b","<c>"]` + "\n"; sb.String() != want {
+		t.Errorf("template json = %q, want %q", sb.String(), want)
+	}
+}
+
+func TestFormatModelsCreatedAt(t *testing.T) {
+	created := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	for _, format := range []string{"json", "ndjson", "yaml"} {
+		t.Run(format, func(t *testing.T) {
+			out, err := FormatModels([]models.Model{{ID: "org/a"}}, format)
+			if err != nil {
+				t.Fatalf("FormatModels error: %v", err)
+			}
+			if strings.Contains(out, "createdAt") {
+				t.Errorf("missing createdAt is written:\n%s", out)
+			}
+
+			out, err = FormatModels([]models.Model{{ID: "org/a", CreatedAt: created}}, format)
+			if err != nil {
+				t.Fatalf("FormatModels error: %v", err)
+			}
+			if !strings.Contains(out, "2024-05-01T12:00:00Z") {
+				t.Errorf("createdAt is missing:\n%s", out)
+			}
+		})
+	}
+}
+
+func TestCompactNumber(t *testing.T) {
+	tests := []struct {
+		n    int
+		want string
+	}{
+		{0, "0"},
+		{999, "999"},
+		{1000, "1.00K"},
+		{1234, "1.23K"},
+		{9994, "9.99K"},
+		{9995, "10.0K"},
+		{99949, "99.9K"},
+		{99950, "100K"},
+		{999499, "999K"},
+		{999500, "1.00M"},
+		{999600, "1.00M"},
+		{999949, "1.00M"},
+		{1234567, "1.23M"},
+		{999999999, "1.00B"},
+		{12345678901, "12.3B"},
+		{-1500, "-1.50K"},
+	}
+	for _, tt := range tests {
+		if got := CompactNumber(tt.n); got != tt.want {
+			t.Errorf("CompactNumber(%d) = %q, want %q", tt.n, got, tt.want)
+		}
+	}
+
+	if got := FormatCount(8030261248); got != "8.03B" {
+		t.Errorf("FormatCount(8030261248) = %q, want 8.03B", got)
+	}
+	if got := CommaNumber(1234567); got != "1,234,567" {
+		t.Errorf("CommaNumber(1234567) = %q, want 1,234,567", got)
+	}
+}
+
+func TestFitWidths(t *testing.T) {
+	tests := []struct {
+		name     string
+		widths   []int
+		headers  []string
+		maxWidth int
+		want     []int
+	}{
+		{"fits", []int{5, 10}, []string{"ID", "Name"}, 100, []int{5, 10}},
+		{"shrinks the widest", []int{30, 10}, []string{"ID", "Downloads"}, 40, []int{23, 10}},
+		{"narrow columns kept", []int{3, 40}, []string{"ID", "Description"}, 30, []int{3, 20}},
+		{"not below the header or minimum", []int{20, 20}, []string{"Identifier", "X"}, 10, []int{10, 8}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			widths := append([]int{}, tt.widths...)
+			fitWidths(widths, tt.headers, tt.maxWidth)
+			if !slices.Equal(widths, tt.want) {
+				t.Errorf("fitWidths = %v, want %v", widths, tt.want)
+			}
+		})
+	}
+}
+
+func TestDisplayWidth(t *testing.T) {
+	tests := []struct {
+		s    string
+		want int
+	}{
+		{"", 0},
+		{"abc", 3},
+		{"日本語", 6},
+		{"한국어", 6},
+		{"😀", 2},
+		{"é", 1},
+		{"a\tb", 2},
+		{"ｆｕｌｌ", 8},
+	}
+	for _, tt := range tests {
+		if got := DisplayWidth(tt.s); got != tt.want {
+			t.Errorf("DisplayWidth(%q) = %d, want %d", tt.s, got, tt.want)
+		}
+	}
+}
+
+func TestTruncate(t *testing.T) {
+	tests := []struct {
+		s     string
+		width int
+		want  string
+	}{
+		{"abc", 3, "abc"},
+		{"hello world", 5, "hell…"},
+		{"日本語", 6, "日本語"},
+		{"日本語", 5, "日本…"},
+		{"日本語", 4, "日…"},
+		{"a😀b", 3, "a…"},
+		{"abc", 1, "…"},
+		{"abc", 0, ""},
+	}
+	for _, tt := range tests {
+		got := Truncate(tt.s, tt.width)
+		if got != tt.want {
+			t.Errorf("Truncate(%q, %d) = %q, want %q", tt.s, tt.width, got, tt.want)
+		}
+		if DisplayWidth(got) > tt.width {
+			t.Errorf("Truncate(%q, %d) is %d columns wide", tt.s, tt.width, DisplayWidth(got))
+		}
+	}
+}
+
+func TestColorEnabled(t *testing.T) {
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+	defer w.Close()
+
+	// A character device stands in for a terminal
+	tty, err := os.OpenFile(os.DevNull, os.O_WRONLY, 0)
+	if err != nil {
+		t.Skip(err)
+	}
+	defer tty.Close()
+
+	tests := []struct {
+		name    string
+		w       io.Writer
+		noColor string
+		want    bool
+	}{
+		{"terminal", tty, "", true},
+		{"terminal with NO_COLOR", tty, "1", false},
+		{"pipe", w, "", false},
+		{"buffer", &strings.Builder{}, "", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("NO_COLOR", tt.noColor)
+			if got := ColorEnabled(tt.w); got != tt.want {
+				t.Errorf("ColorEnabled = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSelectColumns(t *testing.T) {
+	columns, err := SelectColumns(ModelColumns, []string{"likes", "ID", "Last-Modified", "trending_score"})
+	if err != nil {
+		t.Fatalf("SelectColumns error: %v", err)
+	}
+	var headers []string
+	for _, c := range columns {
+		headers = append(headers, c.Header)
+	}
+	if want := []string{"Likes", "Model ID", "Last Modified", "Trending"}; !slices.Equal(headers, want) {
+		t.Errorf("headers = %q, want %q", headers, want)
+	}
+
+	_, err = SelectColumns(ModelColumns, []string{"id", "stars"})
+	if err == nil {
+		t.Fatal("SelectColumns accepted an unknown column")
+	}
+	if want := `unknown column "stars" (use id, author, downloads, likes, `; !strings.HasPrefix(err.Error(), want) {
+		t.Errorf("error = %q, want it to start with %q", err, want)
+	}
+}
+
+func TestTemplateFormat(t *testing.T) {
+	modelsList := []models.Model{
+		{ID: "org/a", Downloads: 1234567, Tags: []string{"gguf", "en"}, LastModified: time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)},
+		{ID: "org/b", Downloads: 5},
+	}
+	f, err := TemplateFormat(`{{.ID}} {{join .Tags ","}} {{number .Downloads}} {{date .LastModified}}`)
+	if err != nil {
+		t.Fatalf("TemplateFormat error: %v", err)
+	}
+	var sb strings.Builder
+	if err := f.Write(&sb, ModelTable(modelsList)); err != nil {
+		t.Fatalf("Write error: %v", err)
+	}
+	if want := "org/a gguf,en 1,234,567 2024-05-01\norg/b  5 \n"; sb.String() != want {
+		t.Errorf("got %q, want %q", sb.String(), want)
+	}
+
+	if _, err := TemplateFormat("{{.ID"); err == nil || !strings.Contains(err.Error(), "invalid template") {
+		t.Errorf("TemplateFormat of a broken template = %v, want an invalid template error", err)
+	}
+	f, err = TemplateFormat("{{.Stars}}")
+	if err != nil {
+		t.Fatalf("TemplateFormat error: %v", err)
+	}
+	if err := f.Write(io.Discard, ModelTable(modelsList)); err == nil || !strings.Contains(err.Error(), "failed to execute template") {
+		t.Errorf("Write with an unknown field = %v, want an execution error", err)
+	}
+}
+
+func TestRenderTableAlignment(t *testing.T) {
+	rows := [][]string{
+		{"日本語モデル", "1"},
+		{"😀-bot", "22"},
+		{"한국어-ko", "333"},
+		{"plain", "4444"},
+	}
+	out := RenderTable([]string{"ID", "Likes"}, rows)
+	lines := strings.Split(strings.TrimSuffix(out, "\n"), "\n")
+	if len(lines) < len(rows)+3 {
+		t.Fatalf("unexpected table:\n%s", out)
+	}
+
+	// Every line is as wide, and the column separators line up
+	width := DisplayWidth(lines[0])
+	separator := func(line string) int {
+		for _, sep := range []string{"│", "┼", "├", "┤"} {
+			line = strings.ReplaceAll(line, sep, "|")
+		}
+		i := strings.Index(line[1:], "|")
+		return DisplayWidth(line[:i+1])
+	}
+	column := separator(lines[0])
+	for _, line := range lines {
+		if DisplayWidth(line) != width {
+			t.Errorf("line %q is %d columns wide, want %d", line, DisplayWidth(line), width)
+		}
+		if strings.ContainsAny(line, "│┼") && separator(line) != column {
+			t.Errorf("line %q has its separator at column %d, want %d", line, separator(line), column)
+		}
+	}
+	if !strings.Contains(out, "│ 日本語モデル │ 1     │") {
+		t.Errorf("wide cell not padded by display width:\n%s", out)
+	}
+}
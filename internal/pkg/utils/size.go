@@ -0,0 +1,88 @@
+package utils
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// sizeUnits maps size suffixes to their multiplier. Decimal and binary units
+// are both accepted; single letters are binary, as in most memory tooling.
+var sizeUnits = map[string]int64{
+	"":    1,
+	"B":   1,
+	"K":   1 << 10,
+	"KB":  1000,
+	"KIB": 1 << 10,
+	"M":   1 << 20,
+	"MB":  1000 * 1000,
+	"MIB": 1 << 20,
+	"G":   1 << 30,
+	"GB":  1000 * 1000 * 1000,
+	"GIB": 1 << 30,
+	"T":   1 << 40,
+	"TB":  1000 * 1000 * 1000 * 1000,
+	"TIB": 1 << 40,
+}
+
+// ParseSize parses a byte size such as "24GiB", "16GB", "8G" or "512MiB"
+func ParseSize(s string) (int64, error) {
+	s = strings.TrimSpace(s)
+	i := strings.IndexFunc(s, func(r rune) bool {
+		return (r < '0' || r > '9') && r != '.'
+	})
+	if i < 0 {
+		i = len(s)
+	}
+
+	number, unit := s[:i], strings.ToUpper(strings.TrimSpace(s[i:]))
+	value, err := strconv.ParseFloat(number, 64)
+	if err != nil || value < 0 {
+		return 0, fmt.Errorf("invalid size %q", s)
+	}
+	multiplier, ok := sizeUnits[unit]
+	if !ok {
+		return 0, fmt.Errorf("invalid size unit %q in %q", s[i:], s)
+	}
+
+	return int64(value * float64(multiplier)), nil
+}
+
+// FormatBytes formats a byte count with binary units, e.g. "4.37 GiB"
+func FormatBytes(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+
+	div, exp := int64(unit), 0
+	for m := n / unit; m >= unit; m /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.2f %ciB", float64(n)/float64(div), "KMGTPE"[exp])
+}
+
+// FormatCount formats a count with three significant digits and a decimal
+// suffix, e.g. "8.03B", "56.7M" or "567K"
+func FormatCount(n uint64) string {
+	const unit = 1000
+	if n < unit {
+		return strconv.FormatUint(n, 10)
+	}
+
+	// Values that round up to 1000 move to the next unit: 999,999 is 1.00M
+	value, exp := float64(n)/unit, 0
+	for value >= 999.5 && exp < 3 {
+		value /= unit
+		exp++
+	}
+	decimals := 2
+	switch {
+	case value >= 99.95:
+		decimals = 0
+	case value >= 9.995:
+		decimals = 1
+	}
+	return strconv.FormatFloat(value, 'f', decimals, 64) + string("KMBT"[exp])
+}
@@ -0,0 +1,92 @@
+package utils
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/Megatherium/hf-go/internal/models"
+)
+
+// SpaceColumns are the columns available in the Space table, selected by
+// name with SelectColumns, with counts in the comma style
+var SpaceColumns = SpaceColumnsWith(CommaNumber)
+
+// SpaceColumnsWith returns the columns of the Space table with counts
+// displayed in the given style
+func SpaceColumnsWith(number NumberFormat) []Column[models.Space] {
+	return []Column[models.Space]{
+		{Name: "id", Header: "Space ID", Value: func(s models.Space) string { return s.ID }},
+		{Name: "author", Header: "Author", Value: func(s models.Space) string { return s.Author }},
+		{Name: "likes", Header: "Likes", Value: func(s models.Space) string { return strconv.Itoa(s.Likes) },
+			Display: func(s models.Space) string { return number(s.Likes) }},
+		{Name: "sdk", Header: "SDK", Value: func(s models.Space) string { return s.SDK },
+			Display: func(s models.Space) string { return OrNA(s.SDK) }},
+		{Name: "stage", Header: "Stage", Value: spaceStage,
+			Display: func(s models.Space) string { return OrNA(spaceStage(s)) }},
+		{Name: "hardware", Header: "Hardware", Value: spaceHardware,
+			Display: func(s models.Space) string { return OrNA(spaceHardware(s)) }},
+		{Name: "last_modified", Header: "Last Modified", Value: func(s models.Space) string { return formatTime(s.LastModified) },
+			Display: func(s models.Space) string { return OrNA(FormatDate(s.LastModified)) }},
+		{Name: "models", Header: "Models", Value: func(s models.Space) string { return strings.Join(s.Models, ",") },
+			Display: func(s models.Space) string { return OrNA(strings.Join(s.Models, ", ")) }},
+		{Name: "datasets", Header: "Datasets", Value: func(s models.Space) string { return strings.Join(s.Datasets, ",") },
+			Display: func(s models.Space) string { return OrNA(strings.Join(s.Datasets, ", ")) }},
+		{Name: "trending_score", Header: "Trending", Value: func(s models.Space) string {
+			return strconv.FormatFloat(s.TrendingScore, 'f', -1, 64)
+		}},
+		{Name: "private", Header: "Private", Value: func(s models.Space) string { return strconv.FormatBool(s.Private) },
+			Display: func(s models.Space) string { return formatBool(s.Private) }},
+		{Name: "created_at", Header: "Created", Value: func(s models.Space) string { return formatTime(s.CreatedAt) },
+			Display: func(s models.Space) string { return OrNA(FormatDate(s.CreatedAt)) }},
+		{Name: "tags", Header: "Tags", Value: func(s models.Space) string { return strings.Join(s.Tags, ",") },
+			Display: func(s models.Space) string { return strings.Join(s.Tags, ", ") }},
+	}
+}
+
+// spaceStage returns the runtime stage of a Space, empty if not fetched
+func spaceStage(s models.Space) string {
+	if s.Runtime == nil {
+		return ""
+	}
+	return s.Runtime.Stage
+}
+
+// spaceHardware returns the hardware a Space runs on, or the one it
+// requested if it is not running
+func spaceHardware(s models.Space) string {
+	if s.Runtime == nil {
+		return ""
+	}
+	if s.Runtime.Hardware.Current != "" {
+		return s.Runtime.Hardware.Current
+	}
+	return s.Runtime.Hardware.Requested
+}
+
+// DefaultSpaceColumns are the columns of the Space table unless others are
+// selected
+var DefaultSpaceColumns = []string{"id", "author", "likes", "sdk", "last_modified"}
+
+// SpaceRuntimeColumns are added to the default columns when the runtime of
+// the Spaces is fetched
+var SpaceRuntimeColumns = []string{"stage", "hardware"}
+
+// SpaceTable builds the table of Spaces written by every output format,
+// with the default columns
+func SpaceTable(spaces []models.Space) Table {
+	columns, _ := SelectColumns(SpaceColumns, DefaultSpaceColumns)
+	return SpaceTableColumns(spaces, columns)
+}
+
+// SpaceTableColumns is like SpaceTable with the given columns
+func SpaceTableColumns(spaces []models.Space, columns []Column[models.Space]) Table {
+	t := NewTable(spaces, columns)
+	t.Empty = "No Spaces found matching the specified criteria."
+	t.RowColors = make([]string, len(spaces))
+	for i, s := range spaces {
+		if s.Private {
+			t.RowColors[i] = ColorPrivate
+		}
+	}
+	return t
+}
@@ -0,0 +1,40 @@
+package utils
+
+import (
+	"io"
+	"os"
+	"strconv"
+)
+
+// IsTerminal reports whether w is a terminal rather than a pipe or a file
+func IsTerminal(w io.Writer) bool {
+	f, ok := w.(*os.File)
+	if !ok {
+		return false
+	}
+	info, err := f.Stat()
+	if err != nil {
+		return false
+	}
+	return info.Mode()&os.ModeCharDevice != 0
+}
+
+// TerminalWidth returns the width in columns of the terminal w, falling back
+// to $COLUMNS, or 0 if w is not a terminal
+func TerminalWidth(w io.Writer) int {
+	if !IsTerminal(w) {
+		return 0
+	}
+	if cols := terminalColumns(w.(*os.File)); cols > 0 {
+		return cols
+	}
+	cols, _ := strconv.Atoi(os.Getenv("COLUMNS"))
+	return cols
+}
+
+// ColorEnabled reports whether to colour output written to w: only on a
+// terminal, and unless the NO_COLOR environment variable is set
+// (https://no-color.org)
+func ColorEnabled(w io.Writer) bool {
+	return IsTerminal(w) && os.Getenv("NO_COLOR") == ""
+}
@@ -0,0 +1,136 @@
+package utils
+
+import (
+	"strings"
+	"unicode"
+)
+
+// wideRanges are the East Asian Wide and Fullwidth code points, which take
+// two columns in a terminal, including the emoji presentation blocks
+var wideRanges = []struct{ lo, hi rune }{
+	{0x1100, 0x115F},   // Hangul Jamo initial consonants
+	{0x231A, 0x231B},   // watch, hourglass
+	{0x2329, 0x232A},   // angle brackets
+	{0x23E9, 0x23EC},   // media control symbols
+	{0x23F0, 0x23F0},   // alarm clock
+	{0x23F3, 0x23F3},   // hourglass with flowing sand
+	{0x25FD, 0x25FE},   // medium small squares
+	{0x2614, 0x2615},   // umbrella, hot beverage
+	{0x2648, 0x2653},   // zodiac
+	{0x267F, 0x267F},   // wheelchair
+	{0x2693, 0x2693},   // anchor
+	{0x26A1, 0x26A1},   // high voltage
+	{0x26AA, 0x26AB},   // circles
+	{0x26BD, 0x26BE},   // soccer ball, baseball
+	{0x26C4, 0x26C5},   // snowman, sun behind cloud
+	{0x26CE, 0x26CE},   // ophiuchus
+	{0x26D4, 0x26D4},   // no entry
+	{0x26EA, 0x26EA},   // church
+	{0x26F2, 0x26F3},   // fountain, flag in hole
+	{0x26F5, 0x26F5},   // sailboat
+	{0x26FA, 0x26FA},   // tent
+	{0x26FD, 0x26FD},   // fuel pump
+	{0x2705, 0x2705},   // check mark button
+	{0x270A, 0x270B},   // raised fists
+	{0x2728, 0x2728},   // sparkles
+	{0x274C, 0x274C},   // cross mark
+	{0x274E, 0x274E},   // cross mark button
+	{0x2753, 0x2755},   // question and exclamation marks
+	{0x2757, 0x2757},   // exclamation mark
+	{0x2795, 0x2797},   // plus, minus, division
+	{0x27B0, 0x27B0},   // curly loop
+	{0x27BF, 0x27BF},   // double curly loop
+	{0x2B1B, 0x2B1C},   // large squares
+	{0x2B50, 0x2B50},   // star
+	{0x2B55, 0x2B55},   // large circle
+	{0x2E80, 0x303E},   // CJK radicals, Kangxi, CJK symbols and punctuation
+	{0x3041, 0x33FF},   // Hiragana, Katakana, Bopomofo, Hangul compatibility, CJK compatibility
+	{0x3400, 0x4DBF},   // CJK unified ideographs extension A
+	{0x4E00, 0x9FFF},   // CJK unified ideographs
+	{0xA000, 0xA4CF},   // Yi
+	{0xA960, 0xA97F},   // Hangul Jamo extended A
+	{0xAC00, 0xD7A3},   // Hangul syllables
+	{0xF900, 0xFAFF},   // CJK compatibility ideographs
+	{0xFE10, 0xFE19},   // vertical forms
+	{0xFE30, 0xFE6F},   // CJK compatibility forms, small form variants
+	{0xFF00, 0xFF60},   // fullwidth forms
+	{0xFFE0, 0xFFE6},   // fullwidth signs
+	{0x16FE0, 0x16FE4}, // ideographic symbols
+	{0x17000, 0x18CFF}, // Tangut
+	{0x1B000, 0x1B2FF}, // Kana supplement and extensions, Nushu
+	{0x1F004, 0x1F004}, // mahjong tile
+	{0x1F0CF, 0x1F0CF}, // playing card
+	{0x1F18E, 0x1F18E}, // AB button
+	{0x1F191, 0x1F19A}, // squared words
+	{0x1F200, 0x1F251}, // enclosed ideographic supplement
+	{0x1F300, 0x1F64F}, // pictographs, emoticons
+	{0x1F680, 0x1F6FF}, // transport and map symbols
+	{0x1F7E0, 0x1F7EB}, // coloured circles and squares
+	{0x1F90C, 0x1F9FF}, // supplemental symbols and pictographs
+	{0x1FA70, 0x1FAFF}, // symbols and pictographs extended A
+	{0x20000, 0x2FFFD}, // CJK unified ideographs extensions B to F
+	{0x30000, 0x3FFFD}, // CJK unified ideographs extension G and beyond
+}
+
+// RuneWidth returns the number of terminal columns r takes: 0 for control
+// and combining characters, 2 for East Asian wide characters, else 1
+func RuneWidth(r rune) int {
+	switch {
+	case r == 0 || r < ' ' || (r >= 0x7F && r < 0xA0):
+		return 0
+	case unicode.In(r, unicode.Mn, unicode.Me, unicode.Cf) || r == 0x200B:
+		return 0
+	case r < 0x1100:
+		return 1
+	}
+	for _, wide := range wideRanges {
+		if r < wide.lo {
+			return 1
+		}
+		if r <= wide.hi {
+			return 2
+		}
+	}
+	return 1
+}
+
+// DisplayWidth returns the number of terminal columns s takes, which differs
+// from its length in bytes or runes for non-ASCII text
+func DisplayWidth(s string) int {
+	width := 0
+	for _, r := range s {
+		width += RuneWidth(r)
+	}
+	return width
+}
+
+// PadRight pads s with spaces to width terminal columns
+func PadRight(s string, width int) string {
+	if pad := width - DisplayWidth(s); pad > 0 {
+		return s + strings.Repeat(" ", pad)
+	}
+	return s
+}
+
+// Truncate shortens s to at most width terminal columns, replacing the end
+// with an ellipsis if it is cut
+func Truncate(s string, width int) string {
+	if DisplayWidth(s) <= width {
+		return s
+	}
+	if width < 1 {
+		return ""
+	}
+
+	var sb strings.Builder
+	used := 0
+	for _, r := range s {
+		w := RuneWidth(r)
+		if used+w > width-1 {
+			break
+		}
+		sb.WriteRune(r)
+		used += w
+	}
+	return sb.String() + "…"
+}
@@ -0,0 +1,11 @@
+//go:build !linux && !darwin
+
+package utils
+
+import "os"
+
+// terminalColumns returns 0 where the terminal size can't be queried, so
+// that TerminalWidth falls back to $COLUMNS
+func terminalColumns(f *os.File) int {
+	return 0
+}
@@ -0,0 +1,24 @@
+//go:build linux || darwin
+
+package utils
+
+import (
+	"os"
+	"syscall"
+	"unsafe"
+)
+
+// winsize is the terminal size returned by the TIOCGWINSZ ioctl
+type winsize struct {
+	rows, cols, xpixel, ypixel uint16
+}
+
+// terminalColumns returns the width of the terminal f, or 0 if unknown
+func terminalColumns(f *os.File) int {
+	var ws winsize
+	_, _, errno := syscall.Syscall(syscall.SYS_IOCTL, f.Fd(), uintptr(syscall.TIOCGWINSZ), uintptr(unsafe.Pointer(&ws)))
+	if errno != 0 {
+		return 0
+	}
+	return int(ws.cols)
+}
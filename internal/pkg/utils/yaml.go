@@ -0,0 +1,185 @@
+package utils
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// yamlField is a key and value of a JSON object, kept in document order
+type yamlField struct {
+	key   string
+	value interface{}
+}
+
+// writeYAML writes the records as a YAML sequence. The records are encoded
+// to JSON first, so the keys and omitempty behaviour match the JSON output.
+func writeYAML(w io.Writer, t Table) error {
+	records := t.Records
+	if records == nil {
+		records = []interface{}{}
+	}
+	data, err := json.Marshal(records)
+	if err != nil {
+		return fmt.Errorf("failed to marshal YAML: %w", err)
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	v, err := decodeOrdered(dec)
+	if err != nil {
+		return fmt.Errorf("failed to marshal YAML: %w", err)
+	}
+
+	var sb strings.Builder
+	writeYAMLValue(&sb, v, 0)
+	_, err = io.WriteString(w, sb.String())
+	return err
+}
+
+// decodeOrdered decodes the next JSON value, objects as []yamlField
+func decodeOrdered(dec *json.Decoder) (interface{}, error) {
+	tok, err := dec.Token()
+	if err != nil {
+		return nil, err
+	}
+
+	switch tok {
+	case json.Delim('{'):
+		fields := []yamlField{}
+		for dec.More() {
+			key, err := dec.Token()
+			if err != nil {
+				return nil, err
+			}
+			value, err := decodeOrdered(dec)
+			if err != nil {
+				return nil, err
+			}
+			fields = append(fields, yamlField{key: key.(string), value: value})
+		}
+		_, err := dec.Token()
+		return fields, err
+	case json.Delim('['):
+		items := []interface{}{}
+		for dec.More() {
+			item, err := decodeOrdered(dec)
+			if err != nil {
+				return nil, err
+			}
+			items = append(items, item)
+		}
+		_, err := dec.Token()
+		return items, err
+	}
+	return tok, nil
+}
+
+// writeYAMLValue writes v at the given indentation, as a block if it is a
+// non-empty object or list
+func writeYAMLValue(sb *strings.Builder, v interface{}, indent int) {
+	pad := strings.Repeat(" ", indent)
+	switch v := v.(type) {
+	case []yamlField:
+		if len(v) == 0 {
+			sb.WriteString(pad + "{}\n")
+			return
+		}
+		for _, f := range v {
+			sb.WriteString(pad + yamlScalar(f.key) + ":")
+			writeYAMLChild(sb, f.value, indent+2)
+		}
+	case []interface{}:
+		if len(v) == 0 {
+			sb.WriteString(pad + "[]\n")
+			return
+		}
+		for _, item := range v {
+			sb.WriteString(pad + "-")
+			if fields, ok := item.([]yamlField); ok && len(fields) > 0 {
+				// The first key goes on the line of the dash
+				var nested strings.Builder
+				writeYAMLValue(&nested, fields, indent+2)
+				sb.WriteString(" " + strings.TrimPrefix(nested.String(), pad+"  "))
+				continue
+			}
+			writeYAMLChild(sb, item, indent+2)
+		}
+	default:
+		sb.WriteString(pad + yamlScalar(v) + "\n")
+	}
+}
+
+// writeYAMLChild writes a value after a key or dash: scalars and empty
+// collections inline, others as an indented block
+func writeYAMLChild(sb *strings.Builder, v interface{}, indent int) {
+	switch c := v.(type) {
+	case []yamlField:
+		if len(c) > 0 {
+			sb.WriteString("\n")
+			writeYAMLValue(sb, c, indent)
+			return
+		}
+		sb.WriteString(" {}\n")
+	case []interface{}:
+		if len(c) > 0 {
+			sb.WriteString("\n")
+			writeYAMLValue(sb, c, indent)
+			return
+		}
+		sb.WriteString(" []\n")
+	default:
+		sb.WriteString(" " + yamlScalar(v) + "\n")
+	}
+}
+
+// yamlScalar formats a JSON scalar, quoting strings YAML would read as
+// another type or that contain special characters
+func yamlScalar(v interface{}) string {
+	switch v := v.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return strconv.FormatBool(v)
+	case json.Number:
+		return v.String()
+	case string:
+		if yamlNeedsQuotes(v) {
+			return strconv.Quote(v)
+		}
+		return v
+	}
+	return fmt.Sprint(v)
+}
+
+func yamlNeedsQuotes(s string) bool {
+	if s == "" || strings.TrimSpace(s) != s {
+		return true
+	}
+	switch strings.ToLower(s) {
+	case "null", "~", "true", "false", "yes", "no", "on", "off", "y", "n", ".inf", "-.inf", "+.inf", ".nan":
+		return true
+	}
+	// Numbers, including the hexadecimal, octal and underscored integers of YAML 1.1
+	if _, err := strconv.ParseFloat(s, 64); err == nil {
+		return true
+	}
+	if _, err := strconv.ParseInt(s, 0, 64); err == nil {
+		return true
+	}
+	if strings.ContainsAny(s[:1], "-?:,[]{}#&*!|>'\"%@`") {
+		return true
+	}
+	if strings.Contains(s, ": ") || strings.Contains(s, " #") || strings.HasSuffix(s, ":") {
+		return true
+	}
+	for _, r := range s {
+		if r < ' ' || r == 0x7f {
+			return true
+		}
+	}
+	return false
+}
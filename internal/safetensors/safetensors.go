@@ -0,0 +1,192 @@
+// Package safetensors reads the header of safetensors files: the tensor
+// names, dtypes, shapes and data offsets, and the free-form __metadata__,
+// without touching the tensor data. It also parses the
+// model.safetensors.index.json files mapping tensors to shards.
+package safetensors
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"sort"
+)
+
+// MaxHeaderSize is the largest JSON header accepted, as in the reference
+// implementation
+const MaxHeaderSize = 100 << 20
+
+// metadataKey is the header entry holding free-form string metadata
+const metadataKey = "__metadata__"
+
+// ErrInvalidHeader is returned when the data does not start with a valid
+// safetensors header
+var ErrInvalidHeader = errors.New("not a safetensors file")
+
+// Header is the parsed header of a safetensors file
+type Header struct {
+	// Metadata is the free-form __metadata__ entry, e.g. {"format": "pt"}
+	Metadata map[string]string `json:"metadata,omitempty"`
+	// Tensors are ordered by data offset
+	Tensors []TensorInfo `json:"tensors"`
+	// HeaderBytes is the size of the length prefix plus the JSON header
+	HeaderBytes int64 `json:"header_bytes"`
+}
+
+// TensorInfo describes a tensor in the header
+type TensorInfo struct {
+	Name  string   `json:"name"`
+	DType string   `json:"dtype"`
+	Shape []uint64 `json:"shape"`
+	// DataOffsets are the begin and end of the tensor data, relative to the
+	// end of the header
+	DataOffsets [2]uint64 `json:"data_offsets"`
+}
+
+// Elements returns the number of elements in the tensor
+func (t TensorInfo) Elements() uint64 {
+	n := uint64(1)
+	for _, d := range t.Shape {
+		n *= d
+	}
+	return n
+}
+
+// Bytes returns the size of the tensor data
+func (t TensorInfo) Bytes() uint64 {
+	return t.DataOffsets[1] - t.DataOffsets[0]
+}
+
+// ParameterCount returns the total number of elements over all tensors
+func (h *Header) ParameterCount() uint64 {
+	var n uint64
+	for _, t := range h.Tensors {
+		n += t.Elements()
+	}
+	return n
+}
+
+// Parameters returns the number of elements per dtype
+func (h *Header) Parameters() map[string]uint64 {
+	params := make(map[string]uint64)
+	for _, t := range h.Tensors {
+		params[t.DType] += t.Elements()
+	}
+	return params
+}
+
+// ReadFile reads the header of a local safetensors file
+func ReadFile(path string) (*Header, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return Read(f)
+}
+
+// Read parses a safetensors header from r. It makes two reads: the 8-byte
+// little-endian header length, then the JSON header itself.
+func Read(r io.ReaderAt) (*Header, error) {
+	var prefix [8]byte
+	if _, err := r.ReadAt(prefix[:], 0); err != nil {
+		return nil, fmt.Errorf("failed to read header length: %w", err)
+	}
+
+	n := binary.LittleEndian.Uint64(prefix[:])
+	if n < 2 || n > MaxHeaderSize {
+		return nil, fmt.Errorf("%w: header length %d", ErrInvalidHeader, n)
+	}
+
+	data := make([]byte, n)
+	if _, err := r.ReadAt(data, int64(len(prefix))); err != nil {
+		return nil, fmt.Errorf("failed to read header: %w", err)
+	}
+	if data[0] != '{' {
+		return nil, ErrInvalidHeader
+	}
+
+	h, err := parseHeader(data)
+	if err != nil {
+		return nil, err
+	}
+	h.HeaderBytes = int64(len(prefix)) + int64(n)
+	return h, nil
+}
+
+// parseHeader decodes the JSON header
+func parseHeader(data []byte) (*Header, error) {
+	var entries map[string]json.RawMessage
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidHeader, err)
+	}
+
+	h := &Header{Tensors: make([]TensorInfo, 0, len(entries))}
+	for name, raw := range entries {
+		if name == metadataKey {
+			if err := json.Unmarshal(raw, &h.Metadata); err != nil {
+				return nil, fmt.Errorf("failed to parse %s: %w", metadataKey, err)
+			}
+			continue
+		}
+
+		t := TensorInfo{Name: name}
+		if err := json.Unmarshal(raw, &t); err != nil {
+			return nil, fmt.Errorf("failed to parse tensor %q: %w", name, err)
+		}
+		if t.DataOffsets[1] < t.DataOffsets[0] {
+			return nil, fmt.Errorf("%w: tensor %q has data offsets %v", ErrInvalidHeader, name, t.DataOffsets)
+		}
+		h.Tensors = append(h.Tensors, t)
+	}
+
+	sort.Slice(h.Tensors, func(i, j int) bool {
+		a, b := h.Tensors[i], h.Tensors[j]
+		if a.DataOffsets[0] != b.DataOffsets[0] {
+			return a.DataOffsets[0] < b.DataOffsets[0]
+		}
+		return a.Name < b.Name
+	})
+
+	return h, nil
+}
+
+// Index is a sharded checkpoint index such as model.safetensors.index.json
+type Index struct {
+	// Metadata usually holds total_size, the size of all tensor data
+	Metadata map[string]interface{} `json:"metadata,omitempty"`
+	// WeightMap maps tensor names to the shard holding them
+	WeightMap map[string]string `json:"weight_map"`
+}
+
+// ParseIndex decodes a sharded checkpoint index
+func ParseIndex(data []byte) (*Index, error) {
+	var idx Index
+	if err := json.Unmarshal(data, &idx); err != nil {
+		return nil, fmt.Errorf("failed to parse index: %w", err)
+	}
+	if len(idx.WeightMap) == 0 {
+		return nil, errors.New("failed to parse index: empty weight_map")
+	}
+	return &idx, nil
+}
+
+// Shards returns the sorted, deduplicated shard filenames of the index,
+// relative to dir, the directory of the index file in the repository
+func (idx *Index) Shards(dir string) []string {
+	seen := make(map[string]bool)
+	var shards []string
+	for _, file := range idx.WeightMap {
+		file = path.Join(dir, file)
+		if !seen[file] {
+			seen[file] = true
+			shards = append(shards, file)
+		}
+	}
+	sort.Strings(shards)
+	return shards
+}
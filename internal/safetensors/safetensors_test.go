@@ -0,0 +1,151 @@
+package safetensors
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"testing"
+)
+
+// encode builds a safetensors file from its JSON header and tensor data
+func encode(header string, data []byte) []byte {
+	b := binary.LittleEndian.AppendUint64(nil, uint64(len(header)))
+	b = append(b, header...)
+	return append(b, data...)
+}
+
+// testHeader has tensors of several dtypes, out of offset order, padded
+// with spaces as writers do to align the data
+const testHeader = `{"__metadata__":{"format":"pt"},` +
+	`"b.weight":{"dtype":"BF16","shape":[4],"data_offsets":[24,32]},` +
+	`"a.weight":{"dtype":"F32","shape":[2,3],"data_offsets":[0,24]},` +
+	`"c.scale":{"dtype":"I8","shape":[],"data_offsets":[32,33]}}   `
+
+func TestRead(t *testing.T) {
+	file := encode(testHeader, make([]byte, 33))
+	h, err := Read(bytes.NewReader(file))
+	if err != nil {
+		t.Fatalf("Read error: %v", err)
+	}
+
+	if want := map[string]string{"format": "pt"}; !reflect.DeepEqual(h.Metadata, want) {
+		t.Errorf("Metadata = %v, want %v", h.Metadata, want)
+	}
+	if want := int64(8 + len(testHeader)); h.HeaderBytes != want {
+		t.Errorf("HeaderBytes = %d, want %d", h.HeaderBytes, want)
+	}
+
+	want := []struct {
+		name     string
+		dtype    string
+		elements uint64
+		bytes    uint64
+	}{
+		{"a.weight", "F32", 6, 24},
+		{"b.weight", "BF16", 4, 8},
+		{"c.scale", "I8", 1, 1},
+	}
+	if len(h.Tensors) != len(want) {
+		t.Fatalf("got %d tensors, want %d", len(h.Tensors), len(want))
+	}
+	for i, w := range want {
+		tensor := h.Tensors[i]
+		if tensor.Name != w.name || tensor.DType != w.dtype || tensor.Elements() != w.elements || tensor.Bytes() != w.bytes {
+			t.Errorf("tensor %d = %s %s %d elements %d bytes, want %+v",
+				i, tensor.Name, tensor.DType, tensor.Elements(), tensor.Bytes(), w)
+		}
+		// The data size is the element count times the dtype size
+		if size := map[string]uint64{"F32": 4, "BF16": 2, "I8": 1}[w.dtype]; tensor.Bytes() != tensor.Elements()*size {
+			t.Errorf("%s: %d bytes for %d %s elements", tensor.Name, tensor.Bytes(), tensor.Elements(), tensor.DType)
+		}
+	}
+
+	if got := h.ParameterCount(); got != 11 {
+		t.Errorf("ParameterCount() = %d, want 11", got)
+	}
+	if want := map[string]uint64{"F32": 6, "BF16": 4, "I8": 1}; !reflect.DeepEqual(h.Parameters(), want) {
+		t.Errorf("Parameters() = %v, want %v", h.Parameters(), want)
+	}
+
+	// Only the header is read: a file cut right after it parses too
+	if _, err := Read(bytes.NewReader(file[:h.HeaderBytes])); err != nil {
+		t.Errorf("Read of the header alone: %v", err)
+	}
+
+	path := filepath.Join(t.TempDir(), "model.safetensors")
+	if err := os.WriteFile(path, file, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	fromFile, err := ReadFile(path)
+	if err != nil || !reflect.DeepEqual(fromFile, h) {
+		t.Errorf("ReadFile = %+v, %v; want %+v", fromFile, err, h)
+	}
+}
+
+func TestReadInvalid(t *testing.T) {
+	tooLarge := binary.LittleEndian.AppendUint64(nil, MaxHeaderSize+1)
+
+	tests := []struct {
+		name string
+		data []byte
+		// invalid is whether the error is ErrInvalidHeader rather than a read error
+		invalid bool
+	}{
+		{"empty", nil, false},
+		{"truncated length", []byte{10, 0, 0}, false},
+		{"header too large", append(tooLarge, '{', '}'), true},
+		{"header too small", encode("{", nil), true},
+		{"truncated header", encode(testHeader, nil)[:50], false},
+		{"not JSON", encode("[1, 2, 3]", nil), true},
+		{"invalid JSON", encode(`{"a": `, nil), true},
+		{"reversed offsets", encode(`{"a":{"dtype":"F32","shape":[1],"data_offsets":[4,0]}}`, nil), true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Read(bytes.NewReader(tt.data))
+			if err == nil {
+				t.Fatal("Read succeeded")
+			}
+			if errors.Is(err, ErrInvalidHeader) != tt.invalid {
+				t.Errorf("Read error = %v, ErrInvalidHeader: %v", err, !tt.invalid)
+			}
+		})
+	}
+
+	if _, err := Read(bytes.NewReader(encode(`{"__metadata__":{"step":1}}`, nil))); err == nil {
+		t.Error("Read accepted non-string metadata")
+	}
+}
+
+func TestIndexShards(t *testing.T) {
+	idx, err := ParseIndex([]byte(`{
+		"metadata": {"total_size": 1000},
+		"weight_map": {
+			"lm_head.weight": "model-00002-of-00002.safetensors",
+			"model.embed_tokens.weight": "model-00001-of-00002.safetensors",
+			"model.norm.weight": "model-00002-of-00002.safetensors"
+		}
+	}`))
+	if err != nil {
+		t.Fatalf("ParseIndex error: %v", err)
+	}
+
+	if got, want := idx.Shards("."), []string{"model-00001-of-00002.safetensors", "model-00002-of-00002.safetensors"}; !slices.Equal(got, want) {
+		t.Errorf("Shards(.) = %q, want %q", got, want)
+	}
+	if got, want := idx.Shards("text_encoder"), []string{
+		"text_encoder/model-00001-of-00002.safetensors", "text_encoder/model-00002-of-00002.safetensors",
+	}; !slices.Equal(got, want) {
+		t.Errorf("Shards(text_encoder) = %q, want %q", got, want)
+	}
+
+	for _, data := range []string{`{"weight_map": {}}`, `{"metadata": {}}`, `not json`} {
+		if _, err := ParseIndex([]byte(data)); err == nil {
+			t.Errorf("ParseIndex(%s) succeeded", data)
+		}
+	}
+}
@@ -0,0 +1,73 @@
+package hfmodels
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// localDirMetadata is where the download metadata of a local directory lives,
+// as in huggingface_hub: .cache/huggingface/download/<filename>.metadata
+var localDirMetadata = filepath.Join(".cache", "huggingface", "download")
+
+// localDirDownload downloads a file into localDir, laid out as in the
+// repository, and returns its path. The commit and ETag of every file are
+// recorded next to it in the huggingface_hub format, so that files already
+// downloaded, by this library or by the Python one, are not fetched again.
+func (c *Client) localDirDownload(ctx context.Context, repoID, filename, revision, localDir string, cfg downloadConfig) (string, error) {
+	dest := filepath.Join(localDir, filepath.FromSlash(filename))
+	metaPath := filepath.Join(localDir, localDirMetadata, filepath.FromSlash(filename)+".metadata")
+
+	commit, etag, ok := readLocalDirMetadata(metaPath)
+	if ok && commit == revision && fileExists(dest) {
+		return dest, nil
+	}
+
+	meta, err := c.client.GetFileMetadata(ctx, c.client.ResolveURL(repoID, revision, filename))
+	if err != nil {
+		return "", err
+	}
+
+	// Keep the file if it didn't change since the recorded commit
+	if !ok || etag != meta.ETag || !fileExists(dest) {
+		if err := c.fetchFile(ctx, meta, dest, cfg); err != nil {
+			return "", err
+		}
+	}
+
+	if err := writeLocalDirMetadata(metaPath, meta.CommitHash, meta.ETag); err != nil {
+		return "", err
+	}
+	return dest, nil
+}
+
+// readLocalDirMetadata reads the commit and ETag a local file was downloaded at
+func readLocalDirMetadata(path string) (commit, etag string, ok bool) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", "", false
+	}
+
+	lines := strings.Split(string(data), "\n")
+	if len(lines) < 2 {
+		return "", "", false
+	}
+	return strings.TrimSpace(lines[0]), strings.TrimSpace(lines[1]), true
+}
+
+// writeLocalDirMetadata records the commit and ETag a local file was downloaded at
+func writeLocalDirMetadata(path, commit, etag string) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("failed to create metadata directory: %w", err)
+	}
+
+	timestamp := strconv.FormatFloat(float64(time.Now().UnixNano())/1e9, 'f', 6, 64)
+	if err := os.WriteFile(path, []byte(commit+"\n"+etag+"\n"+timestamp+"\n"), 0o644); err != nil {
+		return fmt.Errorf("failed to write metadata: %w", err)
+	}
+	return nil
+}
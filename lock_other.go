@@ -0,0 +1,8 @@
+//go:build !unix
+
+package hfmodels
+
+// lockBlob is a no-op where advisory file locks aren't available
+func lockBlob(cacheDir, repoFolder, etag string) (func(), error) {
+	return func() {}, nil
+}
@@ -0,0 +1,34 @@
+//go:build unix
+
+package hfmodels
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"syscall"
+)
+
+// lockBlob takes the same exclusive lock huggingface_hub takes while
+// downloading a blob (<cache>/.locks/<repo folder>/<etag>.lock), so that
+// concurrent Go and Python processes don't download it twice
+func lockBlob(cacheDir, repoFolder, etag string) (func(), error) {
+	path := filepath.Join(cacheDir, ".locks", repoFolder, etag+".lock")
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create lock directory: %w", err)
+	}
+
+	f, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE, 0o644)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open lock file: %w", err)
+	}
+	if err := syscall.Flock(int(f.Fd()), syscall.LOCK_EX); err != nil {
+		f.Close()
+		return nil, fmt.Errorf("failed to lock %s: %w", path, err)
+	}
+
+	return func() {
+		syscall.Flock(int(f.Fd()), syscall.LOCK_UN)
+		f.Close()
+	}, nil
+}
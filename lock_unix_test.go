@@ -0,0 +1,43 @@
+//go:build unix
+
+package hfmodels
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+)
+
+func TestCachedDownloadConcurrent(t *testing.T) {
+	content := []byte("model weights")
+	etag := sha256Hex(content)
+	srv := newFileServer(t, content, etag, "honour")
+	cache := t.TempDir()
+	client := NewClient("", WithEndpoint(srv.URL), WithCacheDir(cache))
+
+	// Concurrent downloads of a blob wait for each other's lock
+	var wg sync.WaitGroup
+	errs := make([]error, 8)
+	for i := range errs {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			_, errs[i] = client.DownloadFile(context.Background(), "org/model", "model.bin", "", "")
+		}(i)
+	}
+	wg.Wait()
+
+	for _, err := range errs {
+		if err != nil {
+			t.Fatalf("DownloadFile error: %v", err)
+		}
+	}
+	if srv.gets != 1 {
+		t.Errorf("got %d GET requests, want 1", srv.gets)
+	}
+	if _, err := os.Stat(filepath.Join(cache, ".locks", "models--org--model", etag+".lock")); err != nil {
+		t.Errorf("no lock file in the huggingface_hub location: %v", err)
+	}
+}
@@ -0,0 +1,367 @@
+package hfmodels
+
+import (
+	"errors"
+	"fmt"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// QuantFamily groups quantization types by scheme
+type QuantFamily string
+
+// Quantization families
+const (
+	// FamilyKQuant covers the k-quants: Q2_K to Q6_K and their _S/_M/_L mixes
+	FamilyKQuant QuantFamily = "k-quant"
+	// FamilyIQuant covers the importance-matrix quants: IQ1_S to IQ4_XS
+	FamilyIQuant QuantFamily = "i-quant"
+	// FamilyLegacy covers the original block quants: Q4_0, Q4_1, Q5_0, Q5_1, Q8_0
+	FamilyLegacy QuantFamily = "legacy"
+	// FamilyTernary covers the ternary quants: TQ1_0, TQ2_0
+	FamilyTernary QuantFamily = "ternary"
+	// FamilyMXFP4 covers the microscaling 4-bit float format
+	FamilyMXFP4 QuantFamily = "mxfp4"
+	// FamilyFloat covers unquantized weights: F32, F16, BF16
+	FamilyFloat QuantFamily = "float"
+)
+
+// ErrQuantNotFound is returned when a repository has no variant of the requested quant
+var ErrQuantNotFound = errors.New("quant not found")
+
+// QuantRole is the purpose of a GGUF file in a repository
+type QuantRole string
+
+// Roles of the GGUF files of a repository
+const (
+	// RoleModel is the main model weights
+	RoleModel QuantRole = "model"
+	// RoleProjector is a multimodal (vision/audio) projector, mmproj-*.gguf
+	RoleProjector QuantRole = "mmproj"
+	// RoleImatrix is an importance matrix used to make i-quants, imatrix.dat or *.imatrix
+	RoleImatrix QuantRole = "imatrix"
+	// RoleLoRA is a LoRA adapter
+	RoleLoRA QuantRole = "lora"
+	// RoleDraft is a small draft model for speculative decoding
+	RoleDraft QuantRole = "draft"
+)
+
+// QuantVariant is a quantization of a model, with the files it is made of
+type QuantVariant struct {
+	// Name is the normalized quant name, e.g. Q4_K_M, IQ3_XXS or UD-Q2_K_XL.
+	// It is empty for files without a quant, such as importance matrices.
+	Name   string      `json:"name"`
+	Role   QuantRole   `json:"role"`
+	Family QuantFamily `json:"family"`
+	// BitsPerWeight is the nominal average bits per weight, 0 if unknown
+	BitsPerWeight float64 `json:"bits_per_weight"`
+	// Size is the total size of the files in bytes, 0 if the sizes are unknown
+	Size int64 `json:"size,omitempty"`
+	// Files lists the files of the variant, ordered by shard
+	Files []QuantFile `json:"files"`
+}
+
+// QuantFile is a file of a quant variant
+type QuantFile struct {
+	Filename string `json:"filename"`
+	// Shard is the 1-based index of a split file, 0 if the file isn't split
+	Shard int `json:"shard,omitempty"`
+	// ShardCount is the number of parts of a split file, 0 if the file isn't split
+	ShardCount int `json:"shard_count,omitempty"`
+	// Size is the file size in bytes, 0 if unknown
+	Size int64 `json:"size,omitempty"`
+}
+
+// Split reports whether the variant is split into several files
+func (v QuantVariant) Split() bool {
+	return len(v.Files) > 1 || (len(v.Files) == 1 && v.Files[0].ShardCount > 1)
+}
+
+// Complete reports whether every shard of a split variant is present
+func (v QuantVariant) Complete() bool {
+	if !v.Split() {
+		return len(v.Files) == 1
+	}
+	return len(v.Files) == v.Files[0].ShardCount
+}
+
+// Filenames returns the names of the variant's files in shard order
+func (v QuantVariant) Filenames() []string {
+	names := make([]string, len(v.Files))
+	for i, f := range v.Files {
+		names[i] = f.Filename
+	}
+	return names
+}
+
+var (
+	// quantNamePattern matches a complete quant name
+	quantNamePattern = regexp.MustCompile(`(?i)^(?:UD-)?(?:I?Q[0-9]+_[A-Z0-9_]+|TQ[0-9]+_[0-9]+|MXFP4(?:_MOE)?|BF16|F16|F32)$`)
+
+	// quantFilePattern finds the quant name in a GGUF filename, optionally
+	// followed by a shard suffix: model-Q4_K_M.gguf, model.UD-IQ2_M-00001-of-00003.gguf
+	quantFilePattern = regexp.MustCompile(`(?i)(?:^|[._-])((?:UD-)?(?:I?Q[0-9]+_[A-Z0-9_]+|TQ[0-9]+_[0-9]+|MXFP4(?:_MOE)?|BF16|F16|F32))(?:-[0-9]+-of-[0-9]+)?\.gguf$`)
+
+	// quantBitsPattern captures the bit width of a Q, IQ or TQ quant name
+	quantBitsPattern = regexp.MustCompile(`^(?:I|T)?Q([0-9]+)_`)
+
+	// shardPattern matches the shard suffix of split GGUF files
+	shardPattern = regexp.MustCompile(`(?i)-([0-9]+)-of-([0-9]+)\.gguf$`)
+
+	// Role patterns, matched against the lowercased file name
+	projectorPattern = regexp.MustCompile(`mmproj`)
+	imatrixPattern   = regexp.MustCompile(`(^|[._-])imatrix([._-]|$)`)
+	loraPattern      = regexp.MustCompile(`(^|[._-])lora([._-]|$)`)
+	draftPattern     = regexp.MustCompile(`(^|[._-])draft([._-]|$)`)
+)
+
+// nominalBitsPerWeight maps quant names to their nominal bits per weight as
+// reported by llama.cpp's quantize tool
+var nominalBitsPerWeight = map[string]float64{
+	"F32":     32,
+	"F16":     16,
+	"BF16":    16,
+	"Q8_0":    8.5,
+	"Q6_K":    6.56,
+	"Q5_1":    6,
+	"Q5_K_M":  5.69,
+	"Q5_K_S":  5.54,
+	"Q5_K":    5.5,
+	"Q5_0":    5.5,
+	"Q4_1":    5,
+	"Q4_K_M":  4.89,
+	"Q4_K_S":  4.58,
+	"Q4_K":    4.5,
+	"Q4_0":    4.5,
+	"IQ4_NL":  4.5,
+	"MXFP4":   4.25,
+	"IQ4_XS":  4.25,
+	"Q3_K_L":  4.27,
+	"Q3_K_M":  3.91,
+	"IQ3_M":   3.66,
+	"Q3_K_S":  3.5,
+	"Q3_K":    3.44,
+	"IQ3_S":   3.44,
+	"IQ3_XS":  3.3,
+	"IQ3_XXS": 3.06,
+	"Q2_K":    2.96,
+	"IQ2_M":   2.7,
+	"Q2_K_S":  2.56,
+	"IQ2_S":   2.5,
+	"IQ2_XS":  2.31,
+	"TQ2_0":   2.06,
+	"IQ2_XXS": 2.06,
+	"IQ1_M":   1.75,
+	"TQ1_0":   1.69,
+	"IQ1_S":   1.56,
+}
+
+// ParseQuantName returns the normalized quant name found in a GGUF file path,
+// or "" if there is none. A quant-named directory takes precedence over the
+// filename, e.g. "BF16/model-00001-of-00002.gguf" is BF16.
+func ParseQuantName(filename string) string {
+	if !strings.HasSuffix(strings.ToLower(filename), ".gguf") {
+		return ""
+	}
+
+	// First check if it's in a quant-named directory
+	if dir, _, ok := strings.Cut(filename, "/"); ok && quantNamePattern.MatchString(dir) {
+		return strings.ToUpper(dir)
+	}
+
+	if m := quantFilePattern.FindStringSubmatch(path.Base(filename)); m != nil {
+		return strings.ToUpper(m[1])
+	}
+	return ""
+}
+
+// QuantRoleOf classifies a repository file by role, or returns "" if it is
+// neither a GGUF file nor an importance matrix
+func QuantRoleOf(filename string) QuantRole {
+	base := strings.ToLower(path.Base(filename))
+	isGGUF := strings.HasSuffix(base, ".gguf")
+
+	switch {
+	case strings.HasSuffix(base, ".imatrix"),
+		imatrixPattern.MatchString(base) && (isGGUF || strings.HasSuffix(base, ".dat")):
+		return RoleImatrix
+	case !isGGUF:
+		return ""
+	case projectorPattern.MatchString(base):
+		return RoleProjector
+	case loraPattern.MatchString(base):
+		return RoleLoRA
+	case draftPattern.MatchString(base):
+		return RoleDraft
+	}
+	return RoleModel
+}
+
+// QuantFamilyOf returns the family of a normalized quant name
+func QuantFamilyOf(name string) QuantFamily {
+	name = strings.TrimPrefix(strings.ToUpper(name), "UD-")
+	switch {
+	case name == "F32" || name == "F16" || name == "BF16":
+		return FamilyFloat
+	case strings.HasPrefix(name, "MXFP4"):
+		return FamilyMXFP4
+	case strings.HasPrefix(name, "TQ"):
+		return FamilyTernary
+	case strings.HasPrefix(name, "IQ"):
+		return FamilyIQuant
+	case strings.Contains(name, "_K"):
+		return FamilyKQuant
+	case strings.HasPrefix(name, "Q"):
+		return FamilyLegacy
+	}
+	return ""
+}
+
+// QuantBitsPerWeight returns the nominal bits per weight of a quant name,
+// falling back to its base type for unknown mixes (e.g. UD-Q4_K_XL uses
+// Q4_K_M's figure, UD-Q8_K_XL Q8_0's), or 0 if the name isn't a quant
+func QuantBitsPerWeight(name string) float64 {
+	name = strings.TrimPrefix(strings.ToUpper(name), "UD-")
+	if bpw, ok := nominalBitsPerWeight[name]; ok {
+		return bpw
+	}
+
+	// Unknown mix suffixes: Q4_K_XL -> Q4_K_M, then Q4_K
+	parts := strings.Split(name, "_")
+	if len(parts) > 2 {
+		base := strings.Join(parts[:2], "_")
+		if bpw, ok := nominalBitsPerWeight[base+"_M"]; ok {
+			return bpw
+		}
+		if bpw, ok := nominalBitsPerWeight[base]; ok {
+			return bpw
+		}
+	}
+	if strings.HasPrefix(name, "MXFP4") {
+		return nominalBitsPerWeight["MXFP4"]
+	}
+
+	// Any other Q<n>, IQ<n> or TQ<n> quant: the k-quant or legacy block
+	// quant of that bit width, else the bit width itself
+	if m := quantBitsPattern.FindStringSubmatch(name); m != nil {
+		for _, base := range []string{"Q" + m[1] + "_K_M", "Q" + m[1] + "_K", "Q" + m[1] + "_0"} {
+			if bpw, ok := nominalBitsPerWeight[base]; ok {
+				return bpw
+			}
+		}
+		bits, _ := strconv.Atoi(m[1])
+		return float64(bits)
+	}
+	return 0
+}
+
+// GroupQuantVariants groups the GGUF files of a repository into quant
+// variants, in order of first appearance. Split files are grouped under a
+// single variant with their files ordered by shard; separate files with the
+// same quant, e.g. of a base and an instruct model, are separate variants.
+// Projectors, importance matrices, LoRA adapters and draft models are
+// grouped separately from the main weights, with their Role set accordingly.
+func GroupQuantVariants(siblings []Sibling) []QuantVariant {
+	var variants []QuantVariant
+	index := make(map[string]int)
+
+	for _, s := range siblings {
+		role := QuantRoleOf(s.RFilename)
+		name := ParseQuantName(s.RFilename)
+		if role == "" || (role == RoleModel && name == "") {
+			continue
+		}
+
+		file := QuantFile{Filename: s.RFilename, Size: s.Size}
+		if m := shardPattern.FindStringSubmatch(s.RFilename); m != nil {
+			file.Shard, _ = strconv.Atoi(m[1])
+			file.ShardCount, _ = strconv.Atoi(m[2])
+		}
+
+		// Files of the same quant are one variant only if they are shards of
+		// the same file: a repository may hold several models in one quant
+		key := string(role) + "/" + name + "/" + shardPattern.ReplaceAllString(s.RFilename, ".gguf")
+		i, ok := index[key]
+		if !ok {
+			i = len(variants)
+			index[key] = i
+			variants = append(variants, QuantVariant{
+				Name:          name,
+				Role:          role,
+				Family:        QuantFamilyOf(name),
+				BitsPerWeight: QuantBitsPerWeight(name),
+			})
+		}
+		variants[i].Files = append(variants[i].Files, file)
+		variants[i].Size += file.Size
+	}
+
+	for i := range variants {
+		files := variants[i].Files
+		sort.SliceStable(files, func(a, b int) bool {
+			return files[a].Shard < files[b].Shard
+		})
+	}
+
+	return variants
+}
+
+// ModelVariants returns the variants holding main model weights
+func ModelVariants(variants []QuantVariant) []QuantVariant {
+	var result []QuantVariant
+	for _, v := range variants {
+		if v.Role == RoleModel {
+			result = append(result, v)
+		}
+	}
+	return result
+}
+
+// MatchingProjector returns the multimodal projector to use with a quant:
+// the projector of the same quant if there is one, else the F16, BF16 or F32
+// one, else the first. It returns false if the repository has no projector.
+func MatchingProjector(variants []QuantVariant, quant string) (QuantVariant, bool) {
+	var projectors []QuantVariant
+	for _, v := range variants {
+		if v.Role == RoleProjector {
+			projectors = append(projectors, v)
+		}
+	}
+	if len(projectors) == 0 {
+		return QuantVariant{}, false
+	}
+
+	for _, name := range []string{strings.ToUpper(quant), "F16", "BF16", "F32"} {
+		for _, p := range projectors {
+			if p.Name == name {
+				return p, true
+			}
+		}
+	}
+	return projectors[0], true
+}
+
+// QuantFiles returns the files needed to run a quant of a model: all shards
+// of the quant, of every model of the repository having it, plus the
+// matching projector for multimodal models
+func QuantFiles(siblings []Sibling, quant string) ([]string, error) {
+	variants := GroupQuantVariants(siblings)
+
+	var files []string
+	for _, v := range ModelVariants(variants) {
+		if strings.EqualFold(v.Name, quant) {
+			files = append(files, v.Filenames()...)
+		}
+	}
+	if len(files) > 0 {
+		if projector, ok := MatchingProjector(variants, quant); ok {
+			files = append(files, projector.Filenames()...)
+		}
+		return files, nil
+	}
+
+	return nil, fmt.Errorf("%w: %s (available: %s)", ErrQuantNotFound, quant, strings.Join(quantNames(variants), ", "))
+}
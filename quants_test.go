@@ -0,0 +1,100 @@
+package hfmodels
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestQuantBitsPerWeight(t *testing.T) {
+	tests := []struct {
+		filename string
+		quant    string
+		bpw      float64
+	}{
+		{"Qwen3-30B-A3B-UD-Q8_K_XL.gguf", "UD-Q8_K_XL", 8.5},
+		{"Qwen3-30B-A3B-UD-Q4_K_XL.gguf", "UD-Q4_K_XL", 4.89},
+		{"Qwen3-30B-A3B-UD-Q6_K_XL.gguf", "UD-Q6_K_XL", 6.56},
+		{"Qwen3-30B-A3B-UD-Q2_K_XL.gguf", "UD-Q2_K_XL", 2.96},
+		{"Qwen3-30B-A3B-UD-IQ2_XXS.gguf", "UD-IQ2_XXS", 2.06},
+		{"Llama-3.2-3B-Instruct-IQ4_NL.gguf", "IQ4_NL", 4.5},
+		{"gpt-oss-20b-MXFP4_MOE.gguf", "MXFP4_MOE", 4.25},
+		{"gpt-oss-20b-MXFP4.gguf", "MXFP4", 4.25},
+		{"Meta-Llama-3.1-8B-Instruct-Q4_K_M.gguf", "Q4_K_M", 4.89},
+		{"Meta-Llama-3.1-8B-Instruct-Q8_0.gguf", "Q8_0", 8.5},
+		{"DeepSeek-R1-UD-IQ1_S-00001-of-00003.gguf", "UD-IQ1_S", 1.56},
+		{"BF16/Qwen3-235B-A22B-BF16-00001-of-00010.gguf", "BF16", 16},
+		{"model-TQ1_0.gguf", "TQ1_0", 1.69},
+		{"model-Q3_K_XL.gguf", "Q3_K_XL", 3.91},
+		{"model-IQ3_KS.gguf", "IQ3_KS", 3.91},
+		{"model-Q7_X.gguf", "Q7_X", 7},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.quant, func(t *testing.T) {
+			quant := ParseQuantName(tt.filename)
+			if quant != tt.quant {
+				t.Fatalf("ParseQuantName(%q) = %q, want %q", tt.filename, quant, tt.quant)
+			}
+			if bpw := QuantBitsPerWeight(quant); bpw != tt.bpw {
+				t.Errorf("QuantBitsPerWeight(%q) = %v, want %v", quant, bpw, tt.bpw)
+			}
+		})
+	}
+}
+
+func TestQuantBitsPerWeightUnknown(t *testing.T) {
+	for _, name := range []string{"", "GGUF", "mmproj"} {
+		if bpw := QuantBitsPerWeight(name); bpw != 0 {
+			t.Errorf("QuantBitsPerWeight(%q) = %v, want 0", name, bpw)
+		}
+	}
+}
+
+func TestGroupQuantVariants(t *testing.T) {
+	siblings := []Sibling{
+		{RFilename: "Llama-3-8B-Q4_K_M.gguf", Size: 100},
+		{RFilename: "Llama-3-8B-Instruct-Q4_K_M.gguf", Size: 200},
+		{RFilename: "Q8_0/Llama-3-8B-Q8_0-00002-of-00002.gguf", Size: 20},
+		{RFilename: "Q8_0/Llama-3-8B-Q8_0-00001-of-00002.gguf", Size: 10},
+		{RFilename: "BF16/Llama-3-8B-BF16-00001-of-00003.gguf", Size: 30},
+		{RFilename: "mmproj-F16.gguf", Size: 5},
+		{RFilename: "README.md"},
+	}
+	type variant struct {
+		name     string
+		role     QuantRole
+		size     int64
+		files    []string
+		complete bool
+	}
+	want := []variant{
+		{"Q4_K_M", RoleModel, 100, []string{"Llama-3-8B-Q4_K_M.gguf"}, true},
+		{"Q4_K_M", RoleModel, 200, []string{"Llama-3-8B-Instruct-Q4_K_M.gguf"}, true},
+		{"Q8_0", RoleModel, 30, []string{"Q8_0/Llama-3-8B-Q8_0-00001-of-00002.gguf", "Q8_0/Llama-3-8B-Q8_0-00002-of-00002.gguf"}, true},
+		{"BF16", RoleModel, 30, []string{"BF16/Llama-3-8B-BF16-00001-of-00003.gguf"}, false},
+		{"F16", RoleProjector, 5, []string{"mmproj-F16.gguf"}, true},
+	}
+
+	variants := GroupQuantVariants(siblings)
+	if len(variants) != len(want) {
+		t.Fatalf("got %d variants, want %d: %+v", len(variants), len(want), variants)
+	}
+	for i, v := range variants {
+		w := want[i]
+		if v.Name != w.name || v.Role != w.role || v.Size != w.size || !slices.Equal(v.Filenames(), w.files) || v.Complete() != w.complete {
+			t.Errorf("variant %d = {%s %s %d %q complete=%v}, want %+v", i, v.Name, v.Role, v.Size, v.Filenames(), v.Complete(), w)
+		}
+	}
+
+	if got, want := ExtractQuantsFromSiblings(siblings), []string{"Q4_K_M", "Q8_0", "BF16"}; !slices.Equal(got, want) {
+		t.Errorf("ExtractQuantsFromSiblings = %q, want %q", got, want)
+	}
+
+	files, err := QuantFiles(siblings, "q4_k_m")
+	if err != nil {
+		t.Fatalf("QuantFiles error: %v", err)
+	}
+	if want := []string{"Llama-3-8B-Q4_K_M.gguf", "Llama-3-8B-Instruct-Q4_K_M.gguf", "mmproj-F16.gguf"}; !slices.Equal(files, want) {
+		t.Errorf("QuantFiles = %q, want %q", files, want)
+	}
+}
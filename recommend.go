@@ -0,0 +1,234 @@
+package hfmodels
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/Megatherium/hf-go/internal/gguf"
+)
+
+// Defaults used by RecommendQuant
+const (
+	// DefaultFitContextLength is the context length assumed when none is given
+	DefaultFitContextLength = 4096
+	// DefaultFitOverhead covers compute buffers and runtime allocations
+	DefaultFitOverhead = 512 << 20
+	// DefaultKVCacheBytes is the size of a KV cache element (f16)
+	DefaultKVCacheBytes = 2
+)
+
+// ErrNoQuantVariants is returned when a repository has no GGUF quant variants
+var ErrNoQuantVariants = errors.New("no GGUF quant variants found")
+
+// ErrUnknownKVCache is returned when the KV cache size can't be computed from
+// the GGUF architecture metadata
+var ErrUnknownKVCache = errors.New("KV cache size unknown from the GGUF metadata")
+
+// FitOptions describes the memory budget a quant variant must fit in
+type FitOptions struct {
+	// Budget is the available RAM/VRAM in bytes
+	Budget int64
+	// ContextLength is the context the model will run with (default 4096)
+	ContextLength int
+	// KVCacheBytes is the size of a KV cache element: 2 for f16, 1 for q8_0 (default 2)
+	KVCacheBytes float64
+	// Overhead is reserved for compute buffers and the runtime (default 512 MiB)
+	Overhead int64
+	// Revision is the branch, tag or commit to inspect (default "main")
+	Revision string
+}
+
+// KVCacheEstimate is the KV cache size derived from GGUF architecture metadata
+type KVCacheEstimate struct {
+	Architecture  string `json:"architecture"`
+	Layers        int    `json:"layers"`
+	KVHeads       int    `json:"kv_heads"`
+	HeadDim       int    `json:"head_dim"`
+	ContextLength int    `json:"context_length"`
+	// Bytes is the estimated size of the KV cache, 0 if the architecture
+	// metadata was incomplete
+	Bytes int64 `json:"bytes"`
+}
+
+// QuantFit is a quant variant with its estimated memory requirement
+type QuantFit struct {
+	Variant QuantVariant `json:"variant"`
+	// Required is the weights, plus the projector of multimodal models, plus
+	// the KV cache and overhead, in bytes
+	Required int64 `json:"required"`
+	Fits     bool  `json:"fits"`
+}
+
+// QuantRecommendation ranks the quant variants of a model for a memory budget
+type QuantRecommendation struct {
+	ModelID string          `json:"model_id"`
+	Budget  int64           `json:"budget"`
+	KVCache KVCacheEstimate `json:"kv_cache"`
+	// Candidates are ordered from highest to lowest expected quality
+	Candidates []QuantFit `json:"candidates"`
+	// Best is the highest quality candidate that fits, nil if none does
+	Best *QuantFit `json:"best"`
+}
+
+// RecommendQuant picks the best GGUF quant variant of a model for a memory
+// budget. Variants are ranked by nominal bits per weight; the memory needed
+// by each is estimated from its real file sizes plus a KV cache computed from
+// the GGUF architecture metadata (layers, KV heads, head dimension) at the
+// requested context length. It returns ErrUnknownKVCache rather than budget
+// no KV cache if the metadata is incomplete.
+func (c *Client) RecommendQuant(ctx context.Context, modelID string, opts FitOptions) (*QuantRecommendation, error) {
+	if opts.ContextLength <= 0 {
+		opts.ContextLength = DefaultFitContextLength
+	}
+	if opts.KVCacheBytes <= 0 {
+		opts.KVCacheBytes = DefaultKVCacheBytes
+	}
+	if opts.Overhead <= 0 {
+		opts.Overhead = DefaultFitOverhead
+	}
+
+	variants, err := c.GetQuantVariantsContext(ctx, modelID, opts.Revision)
+	if err != nil {
+		return nil, err
+	}
+
+	// Shards still being uploaded can't be loaded
+	var complete []QuantVariant
+	for _, v := range ModelVariants(variants) {
+		if v.Complete() {
+			complete = append(complete, v)
+		}
+	}
+	if len(complete) == 0 {
+		return nil, fmt.Errorf("%s: %w", modelID, ErrNoQuantVariants)
+	}
+
+	// Every variant shares the architecture, so any header will do
+	header, err := c.InspectGGUF(ctx, modelID, complete[0].Files[0].Filename, opts.Revision)
+	if err != nil {
+		return nil, err
+	}
+
+	rec := &QuantRecommendation{
+		ModelID: modelID,
+		Budget:  opts.Budget,
+		KVCache: EstimateKVCache(header, opts.ContextLength, opts.KVCacheBytes),
+	}
+	if rec.KVCache.Bytes == 0 {
+		return nil, fmt.Errorf("%s: %w (%q architecture)", modelID, ErrUnknownKVCache, rec.KVCache.Architecture)
+	}
+
+	for _, v := range complete {
+		// QuantFiles downloads the projector along with the weights
+		required := v.Size + rec.KVCache.Bytes + opts.Overhead
+		if projector, ok := MatchingProjector(variants, v.Name); ok {
+			required += projector.Size
+		}
+		rec.Candidates = append(rec.Candidates, QuantFit{
+			Variant:  v,
+			Required: required,
+			Fits:     v.Size > 0 && required <= opts.Budget,
+		})
+	}
+
+	sort.SliceStable(rec.Candidates, func(i, j int) bool {
+		a, b := rec.Candidates[i].Variant, rec.Candidates[j].Variant
+		if a.BitsPerWeight != b.BitsPerWeight {
+			return a.BitsPerWeight > b.BitsPerWeight
+		}
+		return a.Size > b.Size
+	})
+
+	for i := range rec.Candidates {
+		if rec.Candidates[i].Fits {
+			rec.Best = &rec.Candidates[i]
+			break
+		}
+	}
+
+	return rec, nil
+}
+
+// EstimateKVCache computes the KV cache size of a model at contextLength from
+// its GGUF architecture metadata, with bytesPerElement bytes per cached value
+func EstimateKVCache(header *GGUFHeader, contextLength int, bytesPerElement float64) KVCacheEstimate {
+	est := KVCacheEstimate{
+		Architecture:  header.Architecture(),
+		ContextLength: contextLength,
+	}
+
+	layers, _ := header.ArchUint("block_count")
+	embedding, _ := header.ArchUint("embedding_length")
+	est.Layers = int(layers)
+
+	// head_count is a single value or, for hybrid architectures, one value
+	// per layer; the head dimension is the same in every attention layer
+	var heads uint64
+	for _, n := range perLayer(header, "attention.head_count", int(layers)) {
+		heads = max(heads, n)
+	}
+
+	keyLen, hasKey := header.ArchUint("attention.key_length")
+	valueLen, hasValue := header.ArchUint("attention.value_length")
+	if heads > 0 {
+		if !hasKey {
+			keyLen = embedding / heads
+		}
+		if !hasValue {
+			valueLen = embedding / heads
+		}
+	}
+	est.HeadDim = int(keyLen)
+
+	// head_count_kv is either a single value or one value per layer, and
+	// defaults to head_count (no grouped-query attention)
+	kvHeads := perLayer(header, "attention.head_count_kv", int(layers))
+	if kvHeads == nil {
+		kvHeads = perLayer(header, "attention.head_count", int(layers))
+	}
+	if layers == 0 || keyLen+valueLen == 0 || kvHeads == nil {
+		return est
+	}
+	for _, n := range kvHeads {
+		est.KVHeads = max(est.KVHeads, int(n))
+	}
+
+	var elementsPerToken uint64
+	for _, n := range kvHeads {
+		elementsPerToken += n * (keyLen + valueLen)
+	}
+	est.Bytes = int64(float64(elementsPerToken) * float64(contextLength) * bytesPerElement)
+
+	return est
+}
+
+// perLayer returns an architecture key as one value per layer, expanding a
+// scalar, or nil if the key is missing
+func perLayer(header *GGUFHeader, key string, layers int) []uint64 {
+	full := header.Architecture() + "." + key
+	if n, ok := header.Uint(full); ok {
+		values := make([]uint64, layers)
+		for i := range values {
+			values[i] = n
+		}
+		return values
+	}
+
+	value, ok := header.Get(full)
+	if !ok {
+		return nil
+	}
+	array, ok := value.([]interface{})
+	if !ok {
+		return nil
+	}
+
+	values := make([]uint64, 0, len(array))
+	for _, item := range array {
+		n, _ := gguf.ToUint(item)
+		values = append(values, n)
+	}
+	return values
+}
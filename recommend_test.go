@@ -0,0 +1,90 @@
+package hfmodels
+
+import (
+	"testing"
+
+	"github.com/Megatherium/hf-go/internal/gguf"
+)
+
+func testHeader(kvs map[string]interface{}) *GGUFHeader {
+	h := &GGUFHeader{}
+	for k, v := range kvs {
+		h.Metadata = append(h.Metadata, gguf.KeyValue{Key: k, Value: v})
+	}
+	return h
+}
+
+func TestEstimateKVCache(t *testing.T) {
+	tests := []struct {
+		name    string
+		kvs     map[string]interface{}
+		bytes   int64
+		headDim int
+		kvHeads int
+	}{
+		{
+			name: "grouped-query attention",
+			kvs: map[string]interface{}{
+				"general.architecture":          "llama",
+				"llama.block_count":             uint32(32),
+				"llama.embedding_length":        uint32(4096),
+				"llama.attention.head_count":    uint32(32),
+				"llama.attention.head_count_kv": uint32(8),
+			},
+			// 32 layers * 8 heads * (128 + 128) * 1024 tokens * 2 bytes
+			bytes:   32 * 8 * 256 * 1024 * 2,
+			headDim: 128,
+			kvHeads: 8,
+		},
+		{
+			name: "explicit key and value lengths",
+			kvs: map[string]interface{}{
+				"general.architecture":          "gemma",
+				"gemma.block_count":             uint32(2),
+				"gemma.attention.head_count":    uint32(8),
+				"gemma.attention.head_count_kv": uint32(1),
+				"gemma.attention.key_length":    uint32(256),
+				"gemma.attention.value_length":  uint32(256),
+			},
+			bytes:   2 * 1 * 512 * 1024 * 2,
+			headDim: 256,
+			kvHeads: 1,
+		},
+		{
+			name: "per-layer head counts",
+			kvs: map[string]interface{}{
+				"general.architecture":    "hybrid",
+				"hybrid.block_count":      uint32(4),
+				"hybrid.embedding_length": uint32(1024),
+				"hybrid.attention.head_count": []interface{}{
+					uint32(16), uint32(0), uint32(16), uint32(0),
+				},
+				"hybrid.attention.head_count_kv": []interface{}{
+					uint32(4), uint32(0), uint32(4), uint32(0),
+				},
+			},
+			// two attention layers * 4 heads * (64 + 64) * 1024 tokens * 2 bytes
+			bytes:   2 * 4 * 128 * 1024 * 2,
+			headDim: 64,
+			kvHeads: 4,
+		},
+		{
+			name: "missing head count",
+			kvs: map[string]interface{}{
+				"general.architecture":   "llama",
+				"llama.block_count":      uint32(32),
+				"llama.embedding_length": uint32(4096),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			est := EstimateKVCache(testHeader(tt.kvs), 1024, 2)
+			if est.Bytes != tt.bytes || est.HeadDim != tt.headDim || est.KVHeads != tt.kvHeads {
+				t.Errorf("got %d bytes, head dim %d, %d KV heads; want %d, %d, %d",
+					est.Bytes, est.HeadDim, est.KVHeads, tt.bytes, tt.headDim, tt.kvHeads)
+			}
+		})
+	}
+}
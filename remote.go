@@ -0,0 +1,109 @@
+package hfmodels
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/Megatherium/hf-go/internal/api"
+)
+
+// Block sizes of the sequential remote reader, which doubles its requests
+// from minBlockSize up to maxBlockSize to limit round trips on large headers
+const (
+	minBlockSize = 256 << 10
+	maxBlockSize = 16 << 20
+)
+
+// remoteFile reads a repository file with HTTP Range requests
+type remoteFile struct {
+	ctx    context.Context
+	client *api.Client
+	url    string
+	size   int64
+}
+
+// openRemote resolves a repository file without downloading it
+func (c *Client) openRemote(ctx context.Context, repoID, filename, revision string) (*remoteFile, error) {
+	if revision == "" {
+		revision = DefaultRevision
+	}
+
+	meta, err := c.client.GetFileMetadata(ctx, c.client.ResolveURL(repoID, revision, filename))
+	if err != nil {
+		return nil, err
+	}
+
+	return &remoteFile{ctx: ctx, client: c.client, url: meta.Location, size: meta.Size}, nil
+}
+
+// ReadAt reads len(p) bytes at off with a single Range request
+func (f *remoteFile) ReadAt(p []byte, off int64) (int, error) {
+	if len(p) == 0 {
+		return 0, nil
+	}
+	if f.size >= 0 && off >= f.size {
+		return 0, io.EOF
+	}
+
+	header := http.Header{}
+	header.Set("Range", fmt.Sprintf("bytes=%d-%d", off, off+int64(len(p))-1))
+
+	resp, err := f.client.Fetch(f.ctx, f.url, header)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	// A server ignoring the Range header sends the file from the start
+	if resp.StatusCode == http.StatusOK && off > 0 {
+		if _, err := io.CopyN(io.Discard, resp.Body, off); err != nil {
+			return 0, fmt.Errorf("failed to skip to offset %d: %w", off, err)
+		}
+	}
+
+	n, err := io.ReadFull(resp.Body, p)
+	if err == io.ErrUnexpectedEOF {
+		err = io.EOF
+	}
+	return n, err
+}
+
+// reader returns a sequential reader over the file starting at offset 0
+func (f *remoteFile) reader() io.Reader {
+	return &blockReader{r: f, block: minBlockSize}
+}
+
+// blockReader reads sequentially from an io.ReaderAt in growing blocks
+type blockReader struct {
+	r     io.ReaderAt
+	off   int64
+	block int
+	buf   []byte
+	err   error
+}
+
+func (b *blockReader) Read(p []byte) (int, error) {
+	if len(b.buf) == 0 {
+		if b.err != nil {
+			return 0, b.err
+		}
+
+		buf := make([]byte, b.block)
+		n, err := b.r.ReadAt(buf, b.off)
+		b.off += int64(n)
+		b.buf = buf[:n]
+		b.err = err
+		if b.block < maxBlockSize {
+			b.block *= 2
+		}
+		if n == 0 {
+			return 0, b.err
+		}
+	}
+
+	n := copy(p, b.buf)
+	b.buf = b.buf[n:]
+	return n, nil
+}
@@ -0,0 +1,188 @@
+package hfmodels
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/Megatherium/hf-go/internal/safetensors"
+)
+
+// SafetensorsHeader is the parsed header of a safetensors file: tensor names,
+// dtypes, shapes and data offsets, and the free-form __metadata__
+type SafetensorsHeader = safetensors.Header
+
+// SafetensorsTensorInfo describes a tensor in a safetensors header
+type SafetensorsTensorInfo = safetensors.TensorInfo
+
+// ErrNoSafetensors is returned when a repository has no safetensors weights
+var ErrNoSafetensors = errors.New("no safetensors files found")
+
+// Suffixes of safetensors weights and of the indexes of sharded checkpoints
+const (
+	safetensorsSuffix      = ".safetensors"
+	safetensorsIndexSuffix = ".safetensors.index.json"
+)
+
+// SafetensorsFileMetadata is the header of one safetensors file of a repository
+type SafetensorsFileMetadata struct {
+	Filename string `json:"filename"`
+	*SafetensorsHeader
+}
+
+// SafetensorsRepoMetadata summarizes the safetensors weights of a repository
+type SafetensorsRepoMetadata struct {
+	RepoID string `json:"repo_id"`
+	// Commit is the commit hash the headers were read from
+	Commit string `json:"commit"`
+	// Indexes are the sharded checkpoint indexes the files were resolved
+	// from, e.g. model.safetensors.index.json; empty for single-file weights
+	Indexes []string `json:"indexes,omitempty"`
+	// Files are ordered by filename
+	Files []SafetensorsFileMetadata `json:"files"`
+	// Parameters is the number of parameters per dtype over all files
+	Parameters map[string]uint64 `json:"parameters"`
+	// TotalParameters is the number of parameters over all files
+	TotalParameters uint64 `json:"total_parameters"`
+	// TotalBytes is the size of the tensor data over all files
+	TotalBytes uint64 `json:"total_bytes"`
+}
+
+// InspectSafetensors reads the header of a safetensors file on the Hub with
+// two HTTP Range requests, without downloading the tensor data
+func (c *Client) InspectSafetensors(ctx context.Context, repoID, filename, revision string) (*SafetensorsHeader, error) {
+	f, err := c.openRemote(ctx, repoID, filename, revision)
+	if err != nil {
+		return nil, err
+	}
+
+	header, err := safetensors.Read(f)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read safetensors header of %s: %w", filename, err)
+	}
+
+	return header, nil
+}
+
+// InspectSafetensorsFile reads the header of a local safetensors file
+func InspectSafetensorsFile(path string) (*SafetensorsHeader, error) {
+	header, err := safetensors.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read safetensors header of %s: %w", path, err)
+	}
+
+	return header, nil
+}
+
+// InspectSafetensorsRepo reads the headers of all safetensors weights of a
+// repository at revision (default "main"). Sharded checkpoints are resolved
+// through their *.safetensors.index.json, so that only the shards it lists
+// are counted; without an index every *.safetensors file is read.
+func (c *Client) InspectSafetensorsRepo(ctx context.Context, repoID, revision string) (*SafetensorsRepoMetadata, error) {
+	if revision == "" {
+		revision = DefaultRevision
+	}
+
+	details, err := c.GetModelDetailsAtRevisionContext(ctx, repoID, revision)
+	if err != nil {
+		return nil, err
+	}
+
+	// Read every file from the resolved commit, so that a push in between
+	// can't mix revisions
+	commit := details.SHA
+	if commit == "" {
+		commit = revision
+	}
+
+	meta := &SafetensorsRepoMetadata{
+		RepoID:     repoID,
+		Commit:     details.SHA,
+		Parameters: make(map[string]uint64),
+	}
+
+	var files []string
+	for _, s := range details.Siblings {
+		if strings.HasSuffix(s.RFilename, safetensorsIndexSuffix) {
+			meta.Indexes = append(meta.Indexes, s.RFilename)
+		}
+	}
+	for _, index := range meta.Indexes {
+		shards, err := c.safetensorsShards(ctx, repoID, index, commit)
+		if err != nil {
+			return nil, err
+		}
+		// Several indexes may list the same shard: read and count it once
+		for _, shard := range shards {
+			if !slices.Contains(files, shard) {
+				files = append(files, shard)
+			}
+		}
+	}
+	if len(meta.Indexes) == 0 {
+		for _, s := range details.Siblings {
+			if strings.HasSuffix(s.RFilename, safetensorsSuffix) {
+				files = append(files, s.RFilename)
+			}
+		}
+	}
+	if len(files) == 0 {
+		return nil, fmt.Errorf("%s: %w", repoID, ErrNoSafetensors)
+	}
+
+	var mu sync.Mutex
+	err = forEachParallel(ctx, files, DefaultMaxWorkers, func(ctx context.Context, filename string) error {
+		header, err := c.InspectSafetensors(ctx, repoID, filename, commit)
+		if err != nil {
+			return err
+		}
+
+		mu.Lock()
+		defer mu.Unlock()
+		meta.Files = append(meta.Files, SafetensorsFileMetadata{Filename: filename, SafetensorsHeader: header})
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(meta.Files, func(i, j int) bool {
+		return meta.Files[i].Filename < meta.Files[j].Filename
+	})
+	for _, f := range meta.Files {
+		for _, t := range f.Tensors {
+			meta.Parameters[t.DType] += t.Elements()
+			meta.TotalParameters += t.Elements()
+			meta.TotalBytes += t.Bytes()
+		}
+	}
+
+	return meta, nil
+}
+
+// safetensorsShards downloads a sharded checkpoint index and returns the
+// shards it lists
+func (c *Client) safetensorsShards(ctx context.Context, repoID, index, revision string) ([]string, error) {
+	f, err := c.openRemote(ctx, repoID, index, revision)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := io.ReadAll(f.reader())
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", index, err)
+	}
+
+	idx, err := safetensors.ParseIndex(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", index, err)
+	}
+
+	return idx.Shards(path.Dir(index)), nil
+}
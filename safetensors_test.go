@@ -0,0 +1,102 @@
+package hfmodels
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// safetensorsFile builds a safetensors file with one F32 tensor of n elements
+func safetensorsFile(name string, n int) []byte {
+	header := fmt.Sprintf(`{%q:{"dtype":"F32","shape":[%d],"data_offsets":[0,%d]}}`, name, n, 4*n)
+	b := binary.LittleEndian.AppendUint64(nil, uint64(len(header)))
+	b = append(b, header...)
+	return append(b, make([]byte, 4*n)...)
+}
+
+func TestInspectSafetensorsRepo(t *testing.T) {
+	commit := strings.Repeat("a", 40)
+	files := map[string][]byte{
+		// Two indexes listing the same shards, plus one only in the second
+		"model.safetensors.index.json": []byte(`{"weight_map":{
+			"embed": "model-00001-of-00002.safetensors",
+			"head": "model-00002-of-00002.safetensors"}}`),
+		"model.fp32.safetensors.index.json": []byte(`{"weight_map":{
+			"embed": "model-00001-of-00002.safetensors",
+			"head": "model-00002-of-00002.safetensors",
+			"extra": "extra.safetensors"}}`),
+		"model-00001-of-00002.safetensors": safetensorsFile("embed", 100),
+		"model-00002-of-00002.safetensors": safetensorsFile("head", 20),
+		"extra.safetensors":                safetensorsFile("extra", 3),
+		// Not listed by an index, so not counted
+		"stale.safetensors": safetensorsFile("stale", 1000),
+	}
+
+	var (
+		mu   sync.Mutex
+		gets = make(map[string]int)
+	)
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/models/org/model/revision/main", func(w http.ResponseWriter, r *http.Request) {
+		var siblings []string
+		for name := range files {
+			siblings = append(siblings, fmt.Sprintf(`{"rfilename":%q}`, name))
+		}
+		fmt.Fprintf(w, `{"id":"org/model","sha":%q,"siblings":[%s]}`, commit, strings.Join(siblings, ","))
+	})
+	mux.HandleFunc("/org/model/resolve/"+commit+"/{file}", func(w http.ResponseWriter, r *http.Request) {
+		content, ok := files[r.PathValue("file")]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		if r.Method == http.MethodGet {
+			mu.Lock()
+			gets[r.PathValue("file")]++
+			mu.Unlock()
+		}
+		w.Header().Set("X-Repo-Commit", commit)
+		w.Header().Set("ETag", `"etag"`)
+		http.ServeContent(w, r, "", time.Time{}, bytes.NewReader(content))
+	})
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	client := NewClient("", WithEndpoint(server.URL))
+	meta, err := client.InspectSafetensorsRepo(context.Background(), "org/model", "")
+	if err != nil {
+		t.Fatalf("InspectSafetensorsRepo error: %v", err)
+	}
+
+	if meta.Commit != commit || len(meta.Indexes) != 2 {
+		t.Errorf("Commit = %s, Indexes = %q; want %s and both indexes", meta.Commit, meta.Indexes, commit)
+	}
+	var names []string
+	for _, f := range meta.Files {
+		names = append(names, f.Filename)
+	}
+	if want := "extra.safetensors,model-00001-of-00002.safetensors,model-00002-of-00002.safetensors"; strings.Join(names, ",") != want {
+		t.Errorf("Files = %q, want %s", names, want)
+	}
+	if meta.TotalParameters != 123 || meta.Parameters["F32"] != 123 || meta.TotalBytes != 4*123 {
+		t.Errorf("TotalParameters = %d, Parameters = %v, TotalBytes = %d; want 123 F32 parameters in %d bytes",
+			meta.TotalParameters, meta.Parameters, meta.TotalBytes, 4*123)
+	}
+
+	// Every shard header is read once, with the length and JSON requests
+	for _, shard := range names {
+		if gets[shard] != 2 {
+			t.Errorf("%s read with %d requests, want 2", shard, gets[shard])
+		}
+	}
+	if gets["stale.safetensors"] != 0 {
+		t.Error("a file no index lists was read")
+	}
+}
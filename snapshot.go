@@ -0,0 +1,285 @@
+package hfmodels
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// DefaultMaxWorkers is the number of files SnapshotDownload fetches in parallel by default
+const DefaultMaxWorkers = 8
+
+// SnapshotOptions selects which files of a repository SnapshotDownload
+// fetches and where to. Files, Quant and AllowPatterns add up; when none of
+// them is set the whole repository is downloaded.
+type SnapshotOptions struct {
+	// Files are exact filenames to download
+	Files []string
+	// AllowPatterns keeps only the files matching at least one pattern
+	AllowPatterns []string
+	// IgnorePatterns drops the files matching any pattern
+	IgnorePatterns []string
+	// Quant selects all shards of a GGUF quant, e.g. "Q4_K_M", plus the
+	// matching projector of multimodal models
+	Quant string
+	// LocalDir, if set, receives plain copies of the files instead of the
+	// cache, laid out as in the repository
+	LocalDir string
+	// MaxWorkers bounds the number of concurrent downloads (default 8)
+	MaxWorkers int
+	// OnStart, if set, is called with the selected files, with their sizes,
+	// before the downloads start
+	OnStart func(files []Sibling)
+	// Progress, if set, is called as each file downloads, and a last time
+	// with downloaded == total once it is complete or found locally
+	Progress SnapshotProgressFunc
+}
+
+// SnapshotProgressFunc reports the progress of one file of a snapshot
+// download. Calls for different files may be concurrent.
+type SnapshotProgressFunc func(filename string, downloaded, total int64)
+
+// SnapshotDownload downloads the files of a model repository at revision
+// (default "main") into the cache and returns the local snapshot directory,
+// or into opts.LocalDir and returns it.
+//
+// Patterns use shell glob syntax as in huggingface_hub, where "*" also
+// matches "/" and a pattern ending in "/" matches a whole directory, e.g.
+// AllowPatterns: []string{"*Q4_K_M*.gguf", "config.json", "tokenizer*"}.
+func (c *Client) SnapshotDownload(ctx context.Context, repoID, revision string, opts SnapshotOptions) (string, error) {
+	if revision == "" {
+		revision = DefaultRevision
+	}
+
+	details, err := c.getModelDetails(ctx, repoID, revision, true)
+	if err != nil {
+		return "", err
+	}
+	if details.SHA == "" {
+		return "", fmt.Errorf("the Hub did not report a commit hash for %s at %s", repoID, revision)
+	}
+
+	storage := c.RepoCacheDir(repoID)
+	if opts.LocalDir == "" && revision != details.SHA {
+		if err := writeRef(storage, revision, details.SHA); err != nil {
+			return "", err
+		}
+	}
+
+	files, err := selectSnapshotFiles(details.Siblings, opts)
+	if err != nil {
+		return "", err
+	}
+
+	sizes := make(map[string]int64, len(details.Siblings))
+	for _, s := range details.Siblings {
+		sizes[s.RFilename] = s.Size
+	}
+	if opts.OnStart != nil {
+		selected := make([]Sibling, len(files))
+		for i, f := range files {
+			selected[i] = Sibling{RFilename: f, Size: sizes[f]}
+		}
+		opts.OnStart(selected)
+	}
+
+	// Download every file from the resolved commit, so that a push during
+	// the download can't mix revisions
+	err = forEachParallel(ctx, files, opts.MaxWorkers, func(ctx context.Context, filename string) error {
+		var cfg downloadConfig
+		if opts.Progress != nil {
+			cfg.progress = func(downloaded, total int64) {
+				opts.Progress(filename, downloaded, total)
+			}
+		}
+
+		var err error
+		if opts.LocalDir != "" {
+			_, err = c.localDirDownload(ctx, repoID, filename, details.SHA, opts.LocalDir, cfg)
+		} else {
+			_, err = c.cachedDownload(ctx, repoID, filename, details.SHA, cfg)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to download %s: %w", filename, err)
+		}
+
+		if opts.Progress != nil {
+			opts.Progress(filename, sizes[filename], sizes[filename])
+		}
+		return nil
+	})
+	if err != nil {
+		return "", err
+	}
+
+	if opts.LocalDir != "" {
+		return opts.LocalDir, nil
+	}
+	return filepath.Join(storage, "snapshots", details.SHA), nil
+}
+
+// selectSnapshotFiles returns the files of a snapshot download
+func selectSnapshotFiles(siblings []Sibling, opts SnapshotOptions) ([]string, error) {
+	if len(opts.Files) == 0 && opts.Quant == "" {
+		return FilterFiles(siblings, opts.AllowPatterns, opts.IgnorePatterns), nil
+	}
+
+	var files []string
+	for _, f := range opts.Files {
+		if !slices.ContainsFunc(siblings, func(s Sibling) bool { return s.RFilename == f }) {
+			return nil, fmt.Errorf("%s: %w", f, ErrEntryNotFound)
+		}
+		files = append(files, f)
+	}
+	if opts.Quant != "" {
+		quantFiles, err := QuantFiles(siblings, opts.Quant)
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, quantFiles...)
+	}
+	if len(opts.AllowPatterns) > 0 {
+		files = append(files, FilterFiles(siblings, opts.AllowPatterns, nil)...)
+	}
+
+	var selected []string
+	ignore := compileGlobs(opts.IgnorePatterns)
+	for _, f := range files {
+		if !slices.Contains(selected, f) && !ignore.match(f) {
+			selected = append(selected, f)
+		}
+	}
+	return selected, nil
+}
+
+// FilterFiles returns the names of the siblings matching at least one of
+// allow (or all of them if allow is empty) and none of ignore
+func FilterFiles(siblings []Sibling, allow, ignore []string) []string {
+	allowed, ignored := compileGlobs(allow), compileGlobs(ignore)
+
+	var files []string
+	for _, s := range siblings {
+		if len(allow) > 0 && !allowed.match(s.RFilename) {
+			continue
+		}
+		if ignored.match(s.RFilename) {
+			continue
+		}
+		files = append(files, s.RFilename)
+	}
+	return files
+}
+
+// globSet is a list of compiled glob patterns
+type globSet []*regexp.Regexp
+
+// compileGlobs compiles glob patterns once for matching many names. Invalid
+// patterns never match.
+func compileGlobs(patterns []string) globSet {
+	globs := make(globSet, 0, len(patterns))
+	for _, pattern := range patterns {
+		if re, err := globRegexp(pattern); err == nil {
+			globs = append(globs, re)
+		}
+	}
+	return globs
+}
+
+// match reports whether name matches any of the patterns
+func (g globSet) match(name string) bool {
+	for _, re := range g {
+		if re.MatchString(name) {
+			return true
+		}
+	}
+	return false
+}
+
+// globRegexp translates a glob pattern with Python fnmatch semantics to a
+// regular expression: "*" and "?" also match "/", and a trailing "/" matches
+// everything below a directory
+func globRegexp(pattern string) (*regexp.Regexp, error) {
+	if strings.HasSuffix(pattern, "/") {
+		pattern += "*"
+	}
+
+	var expr strings.Builder
+	expr.WriteString("^")
+	runes := []rune(pattern)
+	for i := 0; i < len(runes); i++ {
+		switch ch := runes[i]; ch {
+		case '*':
+			expr.WriteString(".*")
+		case '?':
+			expr.WriteString(".")
+		case '[':
+			end := slices.Index(runes[i+1:], ']')
+			if end < 0 {
+				expr.WriteString(`\[`)
+				continue
+			}
+			class := string(runes[i+1 : i+1+end])
+			if strings.HasPrefix(class, "!") {
+				class = "^" + class[1:]
+			}
+			expr.WriteString("[" + strings.ReplaceAll(class, `\`, `\\`) + "]")
+			i += end + 1
+		default:
+			expr.WriteString(regexp.QuoteMeta(string(ch)))
+		}
+	}
+	expr.WriteString("$")
+
+	return regexp.Compile(expr.String())
+}
+
+// forEachParallel calls fn for every item with at most workers calls in
+// flight. The first error cancels the remaining calls and is returned.
+func forEachParallel(ctx context.Context, items []string, workers int, fn func(context.Context, string) error) error {
+	if workers <= 0 {
+		workers = DefaultMaxWorkers
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		wg       sync.WaitGroup
+		once     sync.Once
+		firstErr error
+	)
+	sem := make(chan struct{}, workers)
+
+	for _, item := range items {
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+		}
+		if ctx.Err() != nil {
+			break
+		}
+
+		wg.Add(1)
+		go func(item string) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			if err := fn(ctx, item); err != nil {
+				once.Do(func() {
+					firstErr = err
+					cancel()
+				})
+			}
+		}(item)
+	}
+	wg.Wait()
+
+	if firstErr != nil {
+		return firstErr
+	}
+	return ctx.Err()
+}
@@ -0,0 +1,91 @@
+package hfmodels
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestGlob(t *testing.T) {
+	tests := []struct {
+		pattern string
+		name    string
+		want    bool
+	}{
+		// * matches any run of characters, including /
+		{"*.json", "config.json", true},
+		{"*.json", "onnx/config.json", true},
+		{"*.json", "config.jsonl", false},
+		{"*", "anything/at/all", true},
+		{"model*.safetensors", "model-00001-of-00002.safetensors", true},
+
+		// ? matches a single character
+		{"?.txt", "a.txt", true},
+		{"?.txt", "ab.txt", false},
+		{"?.txt", ".txt", false},
+		{"model-0000?-of-00002.gguf", "model-00002-of-00002.gguf", true},
+		{"模型?.bin", "模型1.bin", true},
+
+		// [...] matches a class, [!...] its complement
+		{"[abc].txt", "b.txt", true},
+		{"[abc].txt", "d.txt", false},
+		{"[!abc].txt", "b.txt", false},
+		{"[!abc].txt", "d.txt", true},
+		{"[0-9]*.bin", "3x.bin", true},
+		{"[0-9]*.bin", "x3.bin", false},
+		{"[.txt", "[.txt", true},
+
+		// **/ is two stars and a slash: at least one directory
+		{"**/*.json", "a/b/config.json", true},
+		{"**/*.json", "a/config.json", true},
+		{"**/*.json", "config.json", false},
+
+		// A trailing / matches everything below a directory
+		{"onnx/", "onnx/model.onnx", true},
+		{"onnx/", "onnx/fp16/model.onnx", true},
+		{"onnx/", "onnx2/model.onnx", false},
+		{"onnx/", "onnx", false},
+
+		// Dots and other regexp metacharacters are literal
+		{"*.bin", "model_bin", false},
+		{"model.bin", "modelxbin", false},
+		{"a+b.txt", "a+b.txt", true},
+		{"a+b.txt", "aab.txt", false},
+		{"(x)|y", "(x)|y", true},
+		{"(x)|y", "y", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.pattern+" "+tt.name, func(t *testing.T) {
+			if got := compileGlobs([]string{tt.pattern}).match(tt.name); got != tt.want {
+				t.Errorf("%q matching %q = %v, want %v", tt.pattern, tt.name, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFilterFiles(t *testing.T) {
+	siblings := []Sibling{
+		{RFilename: "config.json"},
+		{RFilename: "model.safetensors"},
+		{RFilename: "onnx/model.onnx"},
+		{RFilename: "onnx/config.json"},
+		{RFilename: "README.md"},
+	}
+
+	tests := []struct {
+		allow, ignore []string
+		want          []string
+	}{
+		{nil, nil, []string{"config.json", "model.safetensors", "onnx/model.onnx", "onnx/config.json", "README.md"}},
+		{[]string{"*.json"}, nil, []string{"config.json", "onnx/config.json"}},
+		{[]string{"*.json", "*.md"}, []string{"onnx/"}, []string{"config.json", "README.md"}},
+		{nil, []string{"*.onnx", "*.md"}, []string{"config.json", "model.safetensors", "onnx/config.json"}},
+		{[]string{"*.bin"}, nil, nil},
+	}
+
+	for _, tt := range tests {
+		if got := FilterFiles(siblings, tt.allow, tt.ignore); !slices.Equal(got, tt.want) {
+			t.Errorf("FilterFiles(%q, %q) = %q, want %q", tt.allow, tt.ignore, got, tt.want)
+		}
+	}
+}
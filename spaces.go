@@ -0,0 +1,121 @@
+package hfmodels
+
+import (
+	"context"
+	"net/url"
+
+	"github.com/Megatherium/hf-go/internal/api"
+	"github.com/Megatherium/hf-go/internal/models"
+	"github.com/Megatherium/hf-go/internal/pkg/utils"
+)
+
+// Space represents a HuggingFace Space in a listing
+type Space = models.Space
+
+// SpaceRuntime is the stage and hardware of a Space
+type SpaceRuntime = models.SpaceRuntime
+
+// SpaceHardware is the current and requested hardware of a Space
+type SpaceHardware = models.SpaceHardware
+
+// ListSpacesOptions contains options for listing Spaces
+type ListSpacesOptions = models.ListSpacesOptions
+
+// SpacePager lazily iterates over the pages of a Space listing
+type SpacePager = api.SpacePager
+
+// SpaceDetails contains detailed Space information including files
+type SpaceDetails = models.SpaceDetails
+
+// SpaceCardData contains the metadata of a Space's README
+type SpaceCardData = models.SpaceCardData
+
+// ListSpaces fetches Spaces from HuggingFace Hub, following pagination up to opts.Limit
+func (c *Client) ListSpaces(opts ListSpacesOptions) ([]Space, error) {
+	return c.ListSpacesContext(context.Background(), opts)
+}
+
+// ListSpacesContext is like ListSpaces but the requests are bound to ctx
+func (c *Client) ListSpacesContext(ctx context.Context, opts ListSpacesOptions) ([]Space, error) {
+	return c.client.ListSpacesContext(ctx, opts)
+}
+
+// SpacePages returns a lazy iterator over the pages of a Space listing
+func (c *Client) SpacePages(opts ListSpacesOptions) *SpacePager {
+	return c.SpacePagesContext(context.Background(), opts)
+}
+
+// SpacePagesContext is like SpacePages but the page requests are bound to ctx
+func (c *Client) SpacePagesContext(ctx context.Context, opts ListSpacesOptions) *SpacePager {
+	return c.client.SpacePagesContext(ctx, opts)
+}
+
+// SpacesUsingModel lists the Spaces using a model, i.e. whose models field
+// contains modelID. The other options narrow the listing further.
+func (c *Client) SpacesUsingModel(modelID string, opts ListSpacesOptions) ([]Space, error) {
+	return c.SpacesUsingModelContext(context.Background(), modelID, opts)
+}
+
+// SpacesUsingModelContext is like SpacesUsingModel but the requests are bound to ctx
+func (c *Client) SpacesUsingModelContext(ctx context.Context, modelID string, opts ListSpacesOptions) ([]Space, error) {
+	return c.SpacePagesContext(ctx, SpacesUsingModelOptions(opts, modelID)).All()
+}
+
+// SpacesUsingModelOptions returns opts narrowed to the Spaces using modelID:
+// the Hub selects them by the models parameter, and the models field of
+// every Space is checked again on the client
+func SpacesUsingModelOptions(opts ListSpacesOptions, modelID string) ListSpacesOptions {
+	opts.Models = append(append([]string{}, opts.Models...), modelID)
+	opts.Linked = true
+	match := opts.Match
+	opts.Match = func(s Space) bool {
+		return s.UsesModel(modelID) && (match == nil || match(s))
+	}
+	return opts
+}
+
+// SpaceDetailsOptions selects what GetSpaceDetailsWithOptions fetches
+type SpaceDetailsOptions struct {
+	// Revision is a branch, tag or commit hash; empty means the default branch
+	Revision string
+	// Blobs adds the size, blob ID and LFS information of every sibling
+	Blobs bool
+}
+
+// GetSpaceDetails fetches detailed information about a Space, including its
+// SDK, runtime stage and hardware and the models and datasets it uses
+func (c *Client) GetSpaceDetails(spaceID string) (*SpaceDetails, error) {
+	return c.GetSpaceDetailsContext(context.Background(), spaceID)
+}
+
+// GetSpaceDetailsContext is like GetSpaceDetails but the request is bound to ctx
+func (c *Client) GetSpaceDetailsContext(ctx context.Context, spaceID string) (*SpaceDetails, error) {
+	return c.GetSpaceDetailsWithOptions(ctx, spaceID, SpaceDetailsOptions{})
+}
+
+// GetSpaceDetailsWithOptions fetches Space information at a revision,
+// optionally with the size of every file
+func (c *Client) GetSpaceDetailsWithOptions(ctx context.Context, spaceID string, opts SpaceDetailsOptions) (*SpaceDetails, error) {
+	path := "/api/spaces/" + spaceID
+	if opts.Revision != "" {
+		path += "/revision/" + url.PathEscape(opts.Revision)
+	}
+
+	params := url.Values{}
+	if opts.Blobs {
+		params.Set("blobs", "true")
+	}
+
+	var details SpaceDetails
+	if err := c.client.GetJSON(ctx, path, params, &details); err != nil {
+		return nil, err
+	}
+	details.Author = api.RepoAuthor(details.Author, details.ID)
+
+	return &details, nil
+}
+
+// FormatSpaces formats Spaces in the named output format, like FormatModels
+func FormatSpaces(spaces []Space, format string) (string, error) {
+	return utils.FormatString(format, utils.SpaceTable(spaces))
+}
@@ -0,0 +1,97 @@
+package hfmodels
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+)
+
+func TestSpacesUsingModelOptions(t *testing.T) {
+	base := ListSpacesOptions{
+		Models: make([]string, 1, 4),
+		Match:  func(s Space) bool { return s.Likes >= 10 },
+	}
+	base.Models[0] = "org/other"
+
+	opts := SpacesUsingModelOptions(base, "org/model")
+	if want := []string{"org/other", "org/model"}; !slices.Equal(opts.Models, want) {
+		t.Errorf("Models = %q, want %q", opts.Models, want)
+	}
+	if !opts.Linked {
+		t.Error("Linked is not set")
+	}
+	if got := base.Models[:2]; got[1] != "" {
+		t.Errorf("SpacesUsingModelOptions appended to the Models of opts: %q", got)
+	}
+
+	// Both the model and the existing Match must hold, and repeated calls
+	// require every model
+	tests := []struct {
+		name  string
+		space Space
+		want  bool
+	}{
+		{"uses the model and matches", Space{Likes: 10, Models: []string{"Org/Model"}}, true},
+		{"does not match", Space{Likes: 9, Models: []string{"org/model"}}, false},
+		{"does not use the model", Space{Likes: 10, Models: []string{"org/other"}}, false},
+		{"no models", Space{Likes: 10}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := opts.Match(tt.space); got != tt.want {
+				t.Errorf("Match = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	both := SpacesUsingModelOptions(SpacesUsingModelOptions(ListSpacesOptions{}, "org/a"), "org/b")
+	if both.Match(Space{Models: []string{"org/a"}}) || !both.Match(Space{Models: []string{"org/b", "org/a"}}) {
+		t.Error("Match of two models does not require both")
+	}
+}
+
+func TestSpacesUsingModel(t *testing.T) {
+	var query string
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/spaces", func(w http.ResponseWriter, r *http.Request) {
+		query = r.URL.RawQuery
+		w.Write([]byte(`[
+			{"id":"org/uses","likes":3,"models":["org/model"]},
+			{"id":"org/unrelated","likes":5,"models":["org/other"]},
+			{"id":"org/also","likes":1,"models":["org/other","ORG/MODEL"]}
+		]`))
+	})
+	mux.HandleFunc("GET /api/spaces/org/space", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"id":"org/space"}`))
+	})
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+	client := NewClient("", WithEndpoint(server.URL))
+
+	spaces, err := client.SpacesUsingModelContext(context.Background(), "org/model", ListSpacesOptions{
+		Match: func(s Space) bool { return s.Likes > 1 },
+	})
+	if err != nil {
+		t.Fatalf("SpacesUsingModel error: %v", err)
+	}
+	var ids []string
+	for _, s := range spaces {
+		ids = append(ids, s.ID)
+	}
+	if want := []string{"org/uses"}; !slices.Equal(ids, want) {
+		t.Errorf("Spaces = %q, want %q", ids, want)
+	}
+	if want := "linked=true&models=org%2Fmodel"; query != want {
+		t.Errorf("query = %q, want %q", query, want)
+	}
+
+	details, err := client.GetSpaceDetailsWithOptions(context.Background(), "org/space", SpaceDetailsOptions{})
+	if err != nil {
+		t.Fatalf("GetSpaceDetailsWithOptions error: %v", err)
+	}
+	if details.Author != "org" {
+		t.Errorf("Author = %q, want the namespace org", details.Author)
+	}
+}
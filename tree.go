@@ -0,0 +1,91 @@
+package hfmodels
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/Megatherium/hf-go/internal/models"
+)
+
+// DefaultRevision is the revision used when none is given
+const DefaultRevision = "main"
+
+// RepoTreeEntry is a file or directory in a repository tree listing
+type RepoTreeEntry = models.RepoTreeEntry
+
+// LFSInfo describes a file stored with Git LFS
+type LFSInfo = models.LFSInfo
+
+// LastCommitInfo describes the last commit touching a tree entry
+type LastCommitInfo = models.LastCommitInfo
+
+// RepoTreeOptions controls a repository tree listing
+type RepoTreeOptions struct {
+	// Revision is a branch, tag or commit hash (default "main")
+	Revision string
+	// Path is the directory to list, relative to the repository root
+	Path string
+	// Recursive lists the contents of subdirectories too
+	Recursive bool
+	// Expand fetches the last commit of every entry, which is much slower
+	Expand bool
+}
+
+// ListRepoTree lists the files and directories under path in a model
+// repository, with their sizes and LFS information
+func (c *Client) ListRepoTree(modelID, revision, path string, recursive bool) ([]RepoTreeEntry, error) {
+	return c.ListRepoTreeContext(context.Background(), modelID, RepoTreeOptions{
+		Revision:  revision,
+		Path:      path,
+		Recursive: recursive,
+	})
+}
+
+// ListRepoTreeContext is like ListRepoTree but takes its parameters as
+// options and binds the requests to ctx
+func (c *Client) ListRepoTreeContext(ctx context.Context, modelID string, opts RepoTreeOptions) ([]RepoTreeEntry, error) {
+	revision := opts.Revision
+	if revision == "" {
+		revision = DefaultRevision
+	}
+
+	path := fmt.Sprintf("/api/models/%s/tree/%s", modelID, url.PathEscape(revision))
+	if p := strings.Trim(opts.Path, "/"); p != "" {
+		path += "/" + escapePath(p)
+	}
+
+	params := url.Values{}
+	if opts.Recursive {
+		params.Set("recursive", "true")
+	}
+	if opts.Expand {
+		params.Set("expand", "true")
+	}
+
+	var entries []RepoTreeEntry
+	err := c.client.GetPages(ctx, path, params, func(body []byte) error {
+		var page []RepoTreeEntry
+		if err := json.Unmarshal(body, &page); err != nil {
+			return fmt.Errorf("failed to parse response: %w", err)
+		}
+		entries = append(entries, page...)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return entries, nil
+}
+
+// escapePath escapes every segment of a slash-separated repository path
+func escapePath(p string) string {
+	segments := strings.Split(p, "/")
+	for i, s := range segments {
+		segments[i] = url.PathEscape(s)
+	}
+	return strings.Join(segments, "/")
+}